@@ -2,13 +2,22 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd"
 
 	vault "github.com/hashicorp/vault/api"
 	"github.com/roboll/kube-vault-controller/pkg/controller"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
 	_ "github.com/roboll/kube-vault-controller/pkg/kube/install"
+	"github.com/roboll/kube-vault-controller/pkg/metrics"
 )
 
 var (
@@ -18,13 +27,53 @@ var (
 	namespace  = flag.String("namespace", "", "Namespace to watch for claims.")
 
 	namespacePrefix = flag.String("namespace-prefix", "", "Any claims with this prefix will only be accessible per namespace")
+	pathPolicy      = flag.String("path-policy", "", "(optional) File of allow and deny rules for the paths claims may read, in place of --namespace-prefix.")
+	writePaths      = flag.String("write-paths", "", "Comma separated globs of the paths claims with data may write to, e.g. pki/issue/*. Writes to other paths are denied.")
+	disableWrites   = flag.Bool("disable-writes", false, "Deny all writes of claims with data, whatever the --write-paths.")
 
-	syncPeriod = flag.Duration("sync-period", 0, "Sync all resources each period.")
+	ingressLabel   = flag.String("ingress-label", "", "(optional) Label selector of ingresses to create TLS claims for, e.g. vaultproject.io/tls=true. Empty disables watching ingresses.")
+	ingressPKIPath = flag.String("ingress-pki-path", "", "Vault pki path certificates for ingresses are issued from, e.g. pki/issue/ingress. Required with --ingress-label.")
+
+	kubernetesAuthPath = flag.String("kubernetes-auth-path", "kubernetes", "Mount path of the Vault kubernetes auth method, used by claims with a serviceAccountName.")
+	appRoleAuthPath    = flag.String("approle-auth-path", "approle", "Mount path of the Vault approle auth method, used by claims with an appRoleSecretName.")
+
+	syncPeriod        = flag.Duration("sync-period", 0, "Sync all resources each period.")
+	workers           = flag.Int("workers", 2, "Number of claims synced concurrently.")
+	maxRetries        = flag.Int("max-retries", 10, "Number of times a failed sync is retried, with exponential backoff, before it is dropped until the next change or sync period.")
+	maxRevokeFailures = flag.Int("max-revoke-failures", 10, "Number of times revoking the lease of a deleted claim may fail before its secret is deleted without revoking it, leaving the lease to expire. Zero retries forever.")
+
+	orphanSweepPeriod = flag.Duration("orphan-sweep-period", 10*time.Minute, "How often managed secrets without a claim are revoked and deleted. Zero disables the sweep.")
+	orphanSweepDryRun = flag.Bool("orphan-sweep-dry-run", true, "Only log managed secrets without a claim instead of deleting them. Set to false to revoke and delete them.")
+
+	vaultTokenFile        = flag.String("vault-token-file", "", "(optional) Read the Vault token from this file, reloading it when it changes. Defaults to VAULT_TOKEN.")
+	vaultWrappedTokenFile = flag.String("vault-wrapped-token-file", "", "(optional) Read a response wrapped Vault token from this file and unwrap it at startup. Can't be used with --vault-token-file.")
+
+	leaderElect              = flag.Bool("leader-elect", false, "Elect a leader among replicas, only the leader syncs claims.")
+	leaderElectNamespace     = flag.String("leader-elect-namespace", "kube-system", "Namespace of the leader election config map.")
+	leaderElectName          = flag.String("leader-elect-name", "kube-vault-controller", "Name of the leader election config map.")
+	leaderElectIdentity      = flag.String("leader-elect-identity", "", "Identity of this replica in leader election. Defaults to the hostname.")
+	leaderElectLeaseDuration = flag.Duration("leader-elect-lease-duration", 15*time.Second, "How long replicas wait for the leader to renew before taking over.")
+	leaderElectRenewDeadline = flag.Duration("leader-elect-renew-deadline", 10*time.Second, "How long the leader retries renewing before it gives up leadership.")
+	leaderElectRetryPeriod   = flag.Duration("leader-elect-retry-period", 2*time.Second, "How often the leader renews, and replicas try to acquire, leadership.")
+
+	listenAddress   = flag.String("listen-address", ":8080", "Address to serve /metrics, /healthz and /readyz on, empty to disable.")
+	shutdownTimeout = flag.Duration("shutdown-timeout", 30*time.Second, "How long syncs in flight may take to finish on SIGTERM before leases issued but not stored are revoked.")
+	stallTimeout    = flag.Duration("stall-timeout", 5*time.Minute, "Report not live on /healthz when claims are queued and no sync finished for this long. Zero disables the check.")
+
+	installCRD       = flag.Bool("install-crd", true, "Create the SecretClaim custom resource definition if it does not exist.")
+	printCRD         = flag.Bool("print-crd", false, "Print the SecretClaim custom resource definition and exit.")
+	migrateTPR       = flag.Bool("migrate-tpr", false, "Move claims from the legacy ThirdPartyResource to a custom resource definition and exit.")
+	migrateTPRBackup = flag.String("migrate-tpr-backup", "", "File claims are backed up to before migrating from the ThirdPartyResource, on a volume that outlives the pod. Required with --migrate-tpr.")
 )
 
 func main() {
 	flag.Parse()
 
+	if *printCRD {
+		fmt.Print(kube.CustomResourceDefinition)
+		return
+	}
+
 	log.Printf("kube-vault-controller starting, sync period %s.", *syncPeriod)
 	if *namespace != "" {
 		log.Printf("watching namespace %s.", *namespace)
@@ -32,6 +81,12 @@ func main() {
 	if *namespacePrefix != "" {
 		log.Printf("all secrets with prefix %s will be namespaced", *namespacePrefix)
 	}
+	if *pathPolicy != "" {
+		log.Printf("paths claims may read are restricted by policy %s", *pathPolicy)
+	}
+	if *ingressLabel != "" {
+		log.Printf("creating claims for ingresses matching %s from %s", *ingressLabel, *ingressPKIPath)
+	}
 
 	vconfig := vault.DefaultConfig()
 	err := vconfig.ReadEnvironment()
@@ -47,18 +102,120 @@ func main() {
 		panic(err.Error())
 	}
 
+	if *migrateTPR {
+		if err := kube.MigrateThirdPartyResource(kconfig, *migrateTPRBackup); err != nil {
+			panic(err.Error())
+		}
+		log.Printf("migration finished.")
+		return
+	}
+	if err := kube.EnsureCustomResourceDefinition(kconfig, *installCRD); err != nil {
+		panic(err.Error())
+	}
+
 	config := &controller.Config{
-		Namespace:  *namespace,
-		NamespacePrefix: *namespacePrefix,
-		SyncPeriod: *syncPeriod,
+		Namespace:          *namespace,
+		NamespacePrefix:    *namespacePrefix,
+		PathPolicyFile:     *pathPolicy,
+		WritePaths:         splitList(*writePaths),
+		DisableWrites:      *disableWrites,
+		KubernetesAuthPath: *kubernetesAuthPath,
+		AppRoleAuthPath:    *appRoleAuthPath,
+		SyncPeriod:         *syncPeriod,
+		Workers:            *workers,
+		MaxRetries:         *maxRetries,
+		MaxRevokeFailures:  *maxRevokeFailures,
+		OrphanSweepPeriod:  *orphanSweepPeriod,
+		OrphanSweepDryRun:  *orphanSweepDryRun,
+		StallTimeout:       *stallTimeout,
+		ShutdownTimeout:    *shutdownTimeout,
+
+		VaultTokenFile:        *vaultTokenFile,
+		VaultWrappedTokenFile: *vaultWrappedTokenFile,
+
+		IngressSelector: *ingressLabel,
+		IngressPKIPath:  *ingressPKIPath,
+
+		Metrics: metrics.DefaultRegistry,
+	}
+	if *leaderElect {
+		identity := *leaderElectIdentity
+		if identity == "" {
+			identity, err = os.Hostname()
+			if err != nil {
+				panic(err.Error())
+			}
+		}
+		log.Printf("leader election enabled, identity %s.", identity)
+		config.LeaderElection = &controller.LeaderElectionConfig{
+			Namespace:     *leaderElectNamespace,
+			Name:          *leaderElectName,
+			Identity:      identity,
+			LeaseDuration: *leaderElectLeaseDuration,
+			RenewDeadline: *leaderElectRenewDeadline,
+			RetryPeriod:   *leaderElectRetryPeriod,
+		}
 	}
 	ctrl, err := controller.New(config, vconfig, kconfig)
 	if err != nil {
 		panic(err.Error())
 	}
 
+	if *listenAddress != "" {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.DefaultRegistry)
+		mux.HandleFunc("/healthz", healthHandler(ctrl.Live))
+		mux.HandleFunc("/readyz", healthHandler(ctrl.Ready))
+		go func() {
+			log.Printf("serving metrics on %s.", *listenAddress)
+			if err := http.ListenAndServe(*listenAddress, mux); err != nil {
+				panic(err.Error())
+			}
+		}()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+
 	stop := make(chan struct{})
-	go ctrl.Run(stop)
+	done := make(chan error, 1)
+	go func() {
+		done <- ctrl.Run(stop)
+	}()
 
-	<-make(chan struct{})
+	select {
+	case sig := <-signals:
+		log.Printf("received %s, shutting down.", sig)
+		close(stop)
+		if err := <-done; err != nil {
+			panic(err.Error())
+		}
+		log.Printf("kube-vault-controller stopped.")
+	case err := <-done:
+		if err != nil {
+			panic(err.Error())
+		}
+	}
+}
+
+// healthHandler serves the result of check, 503 with the error if it fails.
+func healthHandler(check func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if err := check(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintln(w, "ok")
+	}
+}
+
+// splitList splits a comma separated flag value, dropping empty elements.
+func splitList(value string) []string {
+	var list []string
+	for _, element := range strings.Split(value, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			list = append(list, element)
+		}
+	}
+	return list
 }
@@ -1,13 +1,23 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
 	"time"
 
 	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"github.com/roboll/kube-vault-controller/pkg/leader"
+	"github.com/roboll/kube-vault-controller/pkg/metrics"
+	"github.com/roboll/kube-vault-controller/pkg/queue"
 	"github.com/roboll/kube-vault-controller/pkg/vault"
 
+	"k8s.io/client-go/kubernetes"
 	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/labels"
+	"k8s.io/client-go/pkg/util/wait"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 )
@@ -15,12 +25,98 @@ import (
 type Controller struct {
 	SecretController      *cache.Controller
 	SecretClaimController *cache.Controller
+	// IngressController is nil unless ingresses are watched.
+	IngressController *cache.Controller
+	Token             *vault.Token
+
+	elector    *leader.Elector
+	manager    kube.SecretClaimManager
+	claims     cache.Store
+	secrets    cache.Store
+	queue      *claimQueue
+	workers    int
+	maxRetries int
+
+	sweepPeriod time.Duration
+	sweepDryRun bool
+
+	ingresses       cache.Store
+	ingressQueue    *queue.Queue
+	ingressSelector labels.Selector
+	ingressPKIPath  string
+	claimClient     *rest.RESTClient
+
+	stallTimeout    time.Duration
+	shutdownTimeout time.Duration
+	// lastProgress is when a worker last finished a sync, in unix nanoseconds.
+	lastProgress int64
 }
 
 type Config struct {
-	Namespace       string
-	NamespacePrefix string
-	SyncPeriod      time.Duration
+	Namespace          string
+	NamespacePrefix    string
+	KubernetesAuthPath string
+	AppRoleAuthPath    string
+	SyncPeriod         time.Duration
+
+	// PathPolicyFile is a file of rules restricting the paths claims may
+	// read, used in place of NamespacePrefix.
+	PathPolicyFile string
+	// WritePaths are globs of the paths claims with data may write to.
+	WritePaths []string
+	// DisableWrites denies all writes of claims with data.
+	DisableWrites bool
+
+	// Workers is the number of claims synced concurrently.
+	Workers int
+	// MaxRetries is the number of times a failed sync is retried before it
+	// is dropped until the next change or sync period.
+	MaxRetries int
+	// MaxRevokeFailures is the number of times revoking the lease of a
+	// deleted claim may fail before its secret is deleted without revoking
+	// it. Zero retries forever.
+	MaxRevokeFailures int
+
+	// OrphanSweepPeriod is how often managed secrets without a claim are
+	// revoked and deleted, zero disables the sweep.
+	OrphanSweepPeriod time.Duration
+	// OrphanSweepDryRun only logs orphaned secrets instead of deleting them.
+	OrphanSweepDryRun bool
+
+	// StallTimeout is how long claims may be queued without any sync
+	// finishing before the controller reports itself not live.
+	StallTimeout time.Duration
+	// ShutdownTimeout is how long syncs in flight may take to finish once the
+	// controller is stopped.
+	ShutdownTimeout time.Duration
+
+	VaultTokenFile        string
+	VaultWrappedTokenFile string
+
+	// LeaderElection enables leader election if set, so only one of several
+	// replicas syncs claims.
+	LeaderElection *LeaderElectionConfig
+
+	// IngressSelector is a label selector of ingresses to create claims for
+	// the tls entries of, empty disables watching ingresses.
+	IngressSelector string
+	// IngressPKIPath is the pki path claims for ingresses are issued from.
+	IngressPKIPath string
+
+	// Metrics is the registry the gauges of the controller are registered
+	// in, nil registers none. A registry can only hold one controller.
+	Metrics *metrics.Registry
+}
+
+// LeaderElectionConfig configures leader election through a config map lock.
+type LeaderElectionConfig struct {
+	Namespace string
+	Name      string
+	Identity  string
+
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
 }
 
 func New(config *Config, vconfig *vaultapi.Config, kconfig *rest.Config) (*Controller, error) {
@@ -32,28 +128,216 @@ func New(config *Config, vconfig *vaultapi.Config, kconfig *rest.Config) (*Contr
 	if err != nil {
 		return nil, err
 	}
-	vaultController, err := vault.NewController(vconfig, kconfig, config.NamespacePrefix)
+	token, err := vault.NewToken(vconfig, &vault.TokenConfig{
+		File:        config.VaultTokenFile,
+		WrappedFile: config.VaultWrappedTokenFile,
+	})
 	if err != nil {
 		return nil, err
 	}
+	var pathPolicy *vault.PathPolicy
+	if config.PathPolicyFile != "" {
+		if config.NamespacePrefix != "" {
+			return nil, errors.New("controller: a namespace prefix and a path policy can't be used together")
+		}
+		pathPolicy, err = vault.LoadPathPolicy(config.PathPolicyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	vaultController, err := vault.NewController(vconfig, kconfig, &vault.Config{
+		NamespacePrefix:    config.NamespacePrefix,
+		PathPolicy:         pathPolicy,
+		WritePaths:         config.WritePaths,
+		DisableWrites:      config.DisableWrites,
+		Token:              token,
+		KubernetesAuthPath: config.KubernetesAuthPath,
+		AppRoleAuthPath:    config.AppRoleAuthPath,
+		MaxRevokeFailures:  config.MaxRevokeFailures,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var elector *leader.Elector
+	if config.LeaderElection != nil {
+		elector, err = newElector(kconfig, config.LeaderElection)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	claims, claimCtrl := cache.NewInformer(claimSource, &kube.SecretClaim{}, config.SyncPeriod, newSecretClaimHandler(vaultController))
-	_, secretCtrl := cache.NewInformer(secretSource, &v1.Secret{}, 0, newSecretHandler(vaultController, claims))
+	claimQueue := newClaimQueue()
+	claims, claimCtrl := cache.NewInformer(claimSource, &kube.SecretClaim{}, config.SyncPeriod, newSecretClaimHandler(claimQueue))
+	secrets, secretCtrl := cache.NewInformer(secretSource, &v1.Secret{}, 0, newSecretHandler(claimQueue, claims))
 
-	return &Controller{
+	var ingresses cache.Store
+	var ingressCtrl *cache.Controller
+	var ingressQueue *queue.Queue
+	var claimClient *rest.RESTClient
+	selector := labels.Everything()
+	if config.IngressSelector != "" {
+		if config.IngressPKIPath == "" {
+			return nil, errors.New("controller: a pki path is required to watch ingresses")
+		}
+		selector, err = labels.Parse(config.IngressSelector)
+		if err != nil {
+			return nil, fmt.Errorf("controller: invalid ingress selector %q: %s", config.IngressSelector, err.Error())
+		}
+		ingressSource, err := newIngressSource(kconfig, config.Namespace, selector)
+		if err != nil {
+			return nil, err
+		}
+		claimClient, err = kube.NewSecretClaimClient(kconfig)
+		if err != nil {
+			return nil, err
+		}
+		ingressQueue = queue.New(queue.NewBackoff(retryBaseDelay, retryMaxDelay))
+		ingresses, ingressCtrl = cache.NewInformer(ingressSource, &kube.Ingress{}, config.SyncPeriod, newIngressHandler(ingressQueue))
+	}
+
+	workers := config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	ctrl := &Controller{
 		SecretController:      secretCtrl,
 		SecretClaimController: claimCtrl,
-	}, nil
+		IngressController:     ingressCtrl,
+		Token:                 token,
+
+		elector:    elector,
+		manager:    vaultController,
+		claims:     claims,
+		secrets:    secrets,
+		queue:      claimQueue,
+		workers:    workers,
+		maxRetries: config.MaxRetries,
+
+		sweepPeriod: config.OrphanSweepPeriod,
+		sweepDryRun: config.OrphanSweepDryRun,
+
+		ingresses:       ingresses,
+		ingressQueue:    ingressQueue,
+		ingressSelector: selector,
+		ingressPKIPath:  config.IngressPKIPath,
+		claimClient:     claimClient,
+
+		stallTimeout:    config.StallTimeout,
+		shutdownTimeout: config.ShutdownTimeout,
+		lastProgress:    time.Now().UnixNano(),
+	}
+	if config.Metrics != nil {
+		ctrl.registerMetrics(config.Metrics)
+	}
+	return ctrl, nil
+}
+
+// Healthy returns an error if the controller can no longer fulfil claims.
+func (ctrl *Controller) Healthy() error {
+	return ctrl.Token.Healthy()
+}
+
+// Run runs the controller until stop is closed. With leader election, claims
+// are only synced while this replica is the leader, and an error is returned
+// if leadership is lost.
+func (ctrl *Controller) Run(stop chan struct{}) error {
+	tokenStop := make(chan struct{})
+	go ctrl.Token.Run(tokenStop)
+	defer close(tokenStop)
+
+	if ctrl.elector == nil {
+		ctrl.run(stop)
+		return nil
+	}
+
+	if err := ctrl.elector.Run(stop, ctrl.run); err != nil {
+		return err
+	}
+	if err := ctrl.elector.Release(); err != nil {
+		log.Printf("controller: failed to release leadership: %s", err.Error())
+	}
+	return nil
 }
 
-func (ctrl *Controller) Run(stop chan struct{}) {
+// run syncs claims until stop is closed. It then stops taking new work and
+// waits up to the shutdown timeout for syncs in flight to finish, before
+// revoking leases that were issued but not stored.
+func (ctrl *Controller) run(stop <-chan struct{}) {
 	secretStop := make(chan struct{})
 	go ctrl.SecretController.Run(secretStop)
 
 	claimStop := make(chan struct{})
 	go ctrl.SecretClaimController.Run(claimStop)
 
+	workers := &sync.WaitGroup{}
+	for i := 0; i < ctrl.workers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			ctrl.runWorker()
+		}()
+	}
+
+	ingressStop := make(chan struct{})
+	if ctrl.IngressController != nil {
+		go ctrl.IngressController.Run(ingressStop)
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			ctrl.runIngressWorker()
+		}()
+	}
+
+	if ctrl.sweepPeriod > 0 {
+		go wait.Until(ctrl.sweepOrphans, ctrl.sweepPeriod, stop)
+	}
+
 	<-stop
-	secretStop <- struct{}{}
-	claimStop <- struct{}{}
+	log.Printf("controller: stopping, waiting up to %s for syncs in flight", ctrl.shutdownTimeout)
+	close(secretStop)
+	close(claimStop)
+	close(ingressStop)
+	ctrl.queue.ShutDown()
+	if ctrl.ingressQueue != nil {
+		ctrl.ingressQueue.ShutDown()
+	}
+
+	ctrl.drain(workers)
+}
+
+// drain waits up to the shutdown timeout for workers to finish, then revokes
+// the leases that were issued but not stored.
+func (ctrl *Controller) drain(workers *sync.WaitGroup) {
+	drained := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		log.Printf("controller: syncs in flight finished")
+	case <-time.After(ctrl.shutdownTimeout):
+		log.Printf("controller: syncs in flight did not finish within %s", ctrl.shutdownTimeout)
+	}
+	ctrl.manager.RevokeIssued()
+}
+
+func newElector(kconfig *rest.Config, config *LeaderElectionConfig) (*leader.Elector, error) {
+	clientset, err := kubernetes.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return leader.NewElector(leader.Config{
+		Lock: &leader.ConfigMapLock{
+			Client:    clientset,
+			Namespace: config.Namespace,
+			Name:      config.Name,
+		},
+		Identity:      config.Identity,
+		LeaseDuration: config.LeaseDuration,
+		RenewDeadline: config.RenewDeadline,
+		RetryPeriod:   config.RetryPeriod,
+	})
 }
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+)
+
+// fakeManager fails syncs and deletes with syncErr and deleteErr, and
+// records the syncs and deletes made and when RevokeIssued is called.
+type fakeManager struct {
+	revoked   chan struct{}
+	syncErr   error
+	deleteErr error
+
+	lock    sync.Mutex
+	forced  []bool
+	deleted []*kube.SecretClaim
+}
+
+func (m *fakeManager) CreateOrUpdateSecret(claim *kube.SecretClaim, force bool) (time.Duration, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.forced = append(m.forced, force)
+	return 0, m.syncErr
+}
+
+func (m *fakeManager) DeleteSecret(claim *kube.SecretClaim) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.deleted = append(m.deleted, claim)
+	return m.deleteErr
+}
+
+// calls returns the syncs and deletes made so far.
+func (m *fakeManager) calls() ([]bool, int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]bool(nil), m.forced...), len(m.deleted)
+}
+
+func (m *fakeManager) RevokeIssued() {
+	close(m.revoked)
+}
+
+func Test_drain(t *testing.T) {
+	tests := []struct {
+		name string
+		// sync is how long the sync in flight takes.
+		sync time.Duration
+		// wantTimeout is whether drain waits for the shutdown timeout.
+		wantTimeout bool
+	}{
+		{name: "syncs finish", sync: 0},
+		{name: "syncs do not finish", sync: time.Hour, wantTimeout: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &fakeManager{revoked: make(chan struct{})}
+			timeout := 200 * time.Millisecond
+			ctrl := &Controller{manager: manager, shutdownTimeout: timeout}
+
+			release := make(chan struct{})
+			defer close(release)
+			workers := &sync.WaitGroup{}
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				select {
+				case <-time.After(tt.sync):
+				case <-release:
+				}
+			}()
+
+			start := time.Now()
+			ctrl.drain(workers)
+			select {
+			case <-manager.revoked:
+			default:
+				t.Fatal("drain() did not revoke issued leases")
+			}
+			if elapsed := time.Since(start); (elapsed >= timeout) != tt.wantTimeout {
+				t.Errorf("drain() took %s, want timeout %t", elapsed, tt.wantTimeout)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// markProgress records that a worker finished a sync.
+func (ctrl *Controller) markProgress() {
+	atomic.StoreInt64(&ctrl.lastProgress, time.Now().UnixNano())
+}
+
+// Live returns an error if claims are queued but no worker finished a sync
+// within the stall timeout, which a restart may fix.
+func (ctrl *Controller) Live() error {
+	if ctrl.stallTimeout <= 0 || ctrl.queue.Len() == 0 {
+		return nil
+	}
+
+	since := time.Since(time.Unix(0, atomic.LoadInt64(&ctrl.lastProgress)))
+	if since > ctrl.stallTimeout {
+		return fmt.Errorf("%d claims queued and no sync finished in %s", ctrl.queue.Len(), since)
+	}
+	return nil
+}
+
+// Ready returns an error unless the controller can sync claims: vault is
+// reachable and unsealed, the controller token is valid and, on the leader,
+// the informers have synced.
+func (ctrl *Controller) Ready() error {
+	if err := ctrl.Healthy(); err != nil {
+		return err
+	}
+
+	status, err := ctrl.Token.Client().Sys().SealStatus()
+	if err != nil {
+		return fmt.Errorf("vault is unreachable: %s", err.Error())
+	}
+	if status.Sealed {
+		return errors.New("vault is sealed")
+	}
+
+	if ctrl.elector != nil && !ctrl.elector.IsLeader() {
+		return nil
+	}
+	if !ctrl.SecretClaimController.HasSynced() {
+		return errors.New("secret claim informer has not synced")
+	}
+	if !ctrl.SecretController.HasSynced() {
+		return errors.New("secret informer has not synced")
+	}
+	if ctrl.IngressController != nil && !ctrl.IngressController.HasSynced() {
+		return errors.New("ingress informer has not synced")
+	}
+	return nil
+}
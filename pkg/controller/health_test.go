@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/leader"
+	"github.com/roboll/kube-vault-controller/pkg/vault"
+	"k8s.io/client-go/pkg/api"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/runtime"
+	"k8s.io/client-go/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// nopLock is a leader lock that is never acquired.
+type nopLock struct{}
+
+func (nopLock) Get() (*leader.Record, error)      { return nil, nil }
+func (nopLock) Create(record leader.Record) error { return nil }
+func (nopLock) Update(record leader.Record) error { return nil }
+func (nopLock) Describe() string                  { return "nop" }
+
+// newInformer returns an informer of an empty list of secrets, synced if
+// stop is not nil.
+func newInformer(t *testing.T, stop chan struct{}) *cache.Controller {
+	source := &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			return &v1.SecretList{}, nil
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			return watch.NewFake(), nil
+		},
+	}
+	_, informer := cache.NewInformer(source, &v1.Secret{}, 0, cache.ResourceEventHandlerFuncs{})
+	if stop == nil {
+		return informer
+	}
+
+	go informer.Run(stop)
+	for i := 0; !informer.HasSynced(); i++ {
+		if i == 100 {
+			t.Fatal("informer did not sync")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return informer
+}
+
+func Test_Live(t *testing.T) {
+	tests := []struct {
+		name         string
+		stallTimeout time.Duration
+		queued       bool
+		lastProgress time.Duration
+		wantErr      bool
+	}{
+		{name: "nothing queued", stallTimeout: time.Minute, lastProgress: time.Hour},
+		{name: "queued with recent progress", stallTimeout: time.Minute, queued: true, lastProgress: time.Second},
+		{name: "queued and stalled", stallTimeout: time.Minute, queued: true, lastProgress: time.Hour, wantErr: true},
+		{name: "stall timeout disabled", queued: true, lastProgress: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &Controller{
+				queue:        newClaimQueue(),
+				stallTimeout: tt.stallTimeout,
+				lastProgress: time.Now().Add(-tt.lastProgress).UnixNano(),
+			}
+			if tt.queued {
+				ctrl.queue.Add("example/app")
+			}
+			if err := ctrl.Live(); (err != nil) != tt.wantErr {
+				t.Errorf("Live() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			ctrl.markProgress()
+			if err := ctrl.Live(); err != nil {
+				t.Errorf("Live() after progress error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func Test_Ready(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	synced := newInformer(t, stop)
+	unsynced := newInformer(t, nil)
+
+	elector, err := leader.NewElector(leader.Config{
+		Lock:          nopLock{},
+		Identity:      "replica",
+		LeaseDuration: 3 * time.Second,
+		RenewDeadline: 2 * time.Second,
+		RetryPeriod:   time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		lookupStatus int
+		sealStatus   int
+		sealed       bool
+		follower     bool
+		claims       *cache.Controller
+		secrets      *cache.Controller
+		ingresses    *cache.Controller
+		wantErr      bool
+	}{
+		{name: "synced", claims: synced, secrets: synced},
+		{name: "synced with ingresses", claims: synced, secrets: synced, ingresses: synced},
+		{name: "claims not synced", claims: unsynced, secrets: synced, wantErr: true},
+		{name: "secrets not synced", claims: synced, secrets: unsynced, wantErr: true},
+		{name: "ingresses not synced", claims: synced, secrets: synced, ingresses: unsynced, wantErr: true},
+		{name: "follower without synced informers", follower: true, claims: unsynced, secrets: unsynced},
+		{name: "token unhealthy", lookupStatus: http.StatusForbidden, claims: synced, secrets: synced, wantErr: true},
+		{name: "vault sealed", sealed: true, claims: synced, secrets: synced, wantErr: true},
+		{name: "follower with vault sealed", sealed: true, follower: true, claims: unsynced, secrets: unsynced, wantErr: true},
+		{name: "vault unreachable", sealStatus: http.StatusInternalServerError, claims: synced, secrets: synced, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch req.URL.Path {
+				case "/v1/auth/token/lookup-self":
+					if tt.lookupStatus != 0 {
+						w.WriteHeader(tt.lookupStatus)
+						fmt.Fprint(w, `{"errors":["permission denied"]}`)
+						return
+					}
+					fmt.Fprint(w, `{"data":{"ttl":0,"renewable":false}}`)
+				case "/v1/sys/seal-status":
+					if tt.sealStatus != 0 {
+						w.WriteHeader(tt.sealStatus)
+						fmt.Fprint(w, `{"errors":["internal error"]}`)
+						return
+					}
+					fmt.Fprintf(w, `{"sealed":%t}`, tt.sealed)
+				default:
+					http.NotFound(w, req)
+				}
+			}))
+			defer server.Close()
+
+			vconfig := vaultapi.DefaultConfig()
+			vconfig.Address = server.URL
+			vconfig.MaxRetries = 0
+			token, err := vault.NewToken(vconfig, &vault.TokenConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctrl := &Controller{
+				Token:                 token,
+				SecretClaimController: tt.claims,
+				SecretController:      tt.secrets,
+				IngressController:     tt.ingresses,
+			}
+			if tt.follower {
+				ctrl.elector = elector
+			}
+			if err := ctrl.Ready(); (err != nil) != tt.wantErr {
+				t.Errorf("Ready() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
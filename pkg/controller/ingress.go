@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"log"
+	"reflect"
+	"strings"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"github.com/roboll/kube-vault-controller/pkg/vault"
+
+	"k8s.io/client-go/pkg/api"
+	kerrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/unversioned"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// IngressLabel is the label of claims created for an ingress, with the name
+// of the ingress.
+const IngressLabel = "vaultproject.io/ingress"
+
+// ingressClaims returns the claims for the tls entries of an ingress, one for
+// each secret name, for the hosts of the entries with that secret name. Entries
+// without a secret name or hosts are skipped.
+func ingressClaims(ingress *kube.Ingress, pkiPath string) []*kube.SecretClaim {
+	var claims []*kube.SecretClaim
+	hostsBySecret := map[string][]string{}
+	for _, tls := range ingress.Spec.TLS {
+		if tls.SecretName == "" || len(tls.Hosts) == 0 {
+			continue
+		}
+		if _, ok := hostsBySecret[tls.SecretName]; !ok {
+			claims = append(claims, ingressClaim(ingress, tls.SecretName, pkiPath))
+		}
+		for _, host := range tls.Hosts {
+			if !containsString(hostsBySecret[tls.SecretName], host) {
+				hostsBySecret[tls.SecretName] = append(hostsBySecret[tls.SecretName], host)
+			}
+		}
+	}
+
+	for _, claim := range claims {
+		hosts := hostsBySecret[claim.Name]
+		claim.Spec.Data = map[string]interface{}{"common_name": hosts[0]}
+		if len(hosts) > 1 {
+			claim.Spec.Data["alt_names"] = strings.Join(hosts[1:], ",")
+		}
+	}
+	return claims
+}
+
+func ingressClaim(ingress *kube.Ingress, name, pkiPath string) *kube.SecretClaim {
+	controller := true
+	return &kube.SecretClaim{
+		TypeMeta: unversioned.TypeMeta{Kind: "SecretClaim", APIVersion: kube.APIGroupVersion},
+		ObjectMeta: api.ObjectMeta{
+			Name:      name,
+			Namespace: ingress.Namespace,
+			Labels: map[string]string{
+				vault.ManagedByLabel: vault.ManagedByValue,
+				IngressLabel:         ingress.Name,
+			},
+			OwnerReferences: []api.OwnerReference{{
+				APIVersion: kube.IngressGroupVersion.String(),
+				Kind:       "Ingress",
+				Name:       ingress.Name,
+				UID:        ingress.UID,
+				Controller: &controller,
+			}},
+		},
+		Spec: kube.SecretSpec{
+			Type:      v1.SecretTypeTLS,
+			Path:      pkiPath,
+			FullChain: true,
+		},
+	}
+}
+
+// ownedByIngress returns whether the claim was created for the named ingress.
+func ownedByIngress(claim *kube.SecretClaim, ingress string) bool {
+	if claim.Labels[IngressLabel] != ingress {
+		return false
+	}
+	for _, ref := range claim.OwnerReferences {
+		if ref.Kind == "Ingress" && ref.Name == ingress {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, element := range list {
+		if element == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (ctrl *Controller) runIngressWorker() {
+	for ctrl.processNextIngress() {
+	}
+}
+
+func (ctrl *Controller) processNextIngress() bool {
+	key, shutdown := ctrl.ingressQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer ctrl.ingressQueue.Done(key)
+
+	if err := ctrl.syncIngress(key); err != nil {
+		if ctrl.ingressQueue.NumRequeues(key) < ctrl.maxRetries {
+			log.Printf("ingress-controller: %s: failed to sync, retrying: %s", key, err.Error())
+			ctrl.ingressQueue.AddRateLimited(key)
+			return true
+		}
+		log.Printf("ingress-controller: %s: failed to sync, dropping: %s", key, err.Error())
+	}
+	ctrl.ingressQueue.Forget(key)
+	return true
+}
+
+// syncIngress creates or updates the claims of an ingress, and deletes the
+// claims it no longer needs, all of them once it is deleted.
+func (ctrl *Controller) syncIngress(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	var desired []*kube.SecretClaim
+	obj, exists, err := ctrl.ingresses.GetByKey(key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		ingress, ok := obj.(*kube.Ingress)
+		if !ok {
+			log.Printf("error: expected *kube.Ingress, got %s", reflect.TypeOf(obj))
+			return nil
+		}
+		// an ingress that no longer matches the selector is treated as
+		// deleted, in case the watch doesn't filter by it.
+		if ctrl.ingressSelector.Matches(labels.Set(ingress.Labels)) {
+			desired = ingressClaims(ingress, ctrl.ingressPKIPath)
+		}
+	}
+
+	for _, claim := range desired {
+		if err := ctrl.ensureIngressClaim(key, claim); err != nil {
+			return err
+		}
+	}
+
+	for _, obj := range ctrl.claims.List() {
+		claim, ok := obj.(*kube.SecretClaim)
+		if !ok || claim.Namespace != namespace || !ownedByIngress(claim, name) {
+			continue
+		}
+		if claimNamed(desired, claim.Name) {
+			continue
+		}
+		log.Printf("ingress-controller: %s: deleting claim %s, no longer in the ingress tls", key, claim.Name)
+		err := ctrl.claimClient.Delete().Namespace(namespace).Resource(kube.ResourceSecretClaims).Name(claim.Name).Do().Error()
+		if err != nil && !kerrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureIngressClaim creates the claim, or updates the spec of the claim if
+// it was created for the same ingress. Claims not created for the ingress are
+// left alone.
+func (ctrl *Controller) ensureIngressClaim(key string, claim *kube.SecretClaim) error {
+	claimKey := claim.Namespace + "/" + claim.Name
+	obj, exists, err := ctrl.claims.GetByKey(claimKey)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Printf("ingress-controller: %s: creating claim %s", key, claim.Name)
+		err := ctrl.claimClient.Post().Namespace(claim.Namespace).Resource(kube.ResourceSecretClaims).Body(claim).Do().Error()
+		if kerrors.IsAlreadyExists(err) {
+			// the claim informer hasn't seen it yet, the next sync updates it.
+			return nil
+		}
+		return err
+	}
+
+	existing, ok := obj.(*kube.SecretClaim)
+	if !ok {
+		log.Printf("error: expected *kube.SecretClaim, got %s", reflect.TypeOf(obj))
+		return nil
+	}
+	if !ownedByIngress(existing, claim.Labels[IngressLabel]) {
+		log.Printf("ingress-controller: %s: skipping claim %s, it was not created for the ingress", key, claim.Name)
+		return nil
+	}
+	if reflect.DeepEqual(existing.Spec, claim.Spec) && reflect.DeepEqual(existing.OwnerReferences, claim.OwnerReferences) {
+		return nil
+	}
+
+	log.Printf("ingress-controller: %s: updating claim %s", key, claim.Name)
+	updated := *existing
+	updated.TypeMeta = claim.TypeMeta
+	updated.Spec = claim.Spec
+	updated.OwnerReferences = claim.OwnerReferences
+	return ctrl.claimClient.Put().Namespace(claim.Namespace).Resource(kube.ResourceSecretClaims).Name(claim.Name).Body(&updated).Do().Error()
+}
+
+func claimNamed(claims []*kube.SecretClaim, name string) bool {
+	for _, claim := range claims {
+		if claim.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"github.com/roboll/kube-vault-controller/pkg/queue"
+
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/labels"
+	"k8s.io/client-go/pkg/runtime"
+	"k8s.io/client-go/pkg/watch"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newIngressHandler(queue *queue.Queue) cache.ResourceEventHandlerFuncs {
+	enqueue := func(obj interface{}, op string) {
+		key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+		if err != nil {
+			log.Printf("error: failed to get key for obj, dropping.")
+			return
+		}
+
+		log.Printf("ingress-handler: %s: handling %s for ingress", key, op)
+		queue.Add(key)
+	}
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			enqueue(obj, "add")
+		},
+		UpdateFunc: func(old, obj interface{}) {
+			enqueue(obj, "update")
+		},
+		DeleteFunc: func(obj interface{}) {
+			enqueue(obj, "delete")
+		},
+	}
+}
+
+// newIngressSource returns a cache.ListerWatcher for ingress objects matching
+// selector.
+func newIngressSource(config *rest.Config, namespace string, selector labels.Selector) (cache.ListerWatcher, error) {
+	client, err := kube.NewIngressClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cache.ListWatch{
+		ListFunc: func(options api.ListOptions) (runtime.Object, error) {
+			req := client.Get().
+				Namespace(namespace).
+				Resource(kube.ResourceIngresses).
+				LabelsSelectorParam(selector)
+			return listParams(req, options).Do().Get()
+		},
+		WatchFunc: func(options api.ListOptions) (watch.Interface, error) {
+			req := client.Get().
+				Prefix("watch").
+				Namespace(namespace).
+				Resource(kube.ResourceIngresses).
+				LabelsSelectorParam(selector)
+			return listParams(req, options).Watch()
+		},
+	}, nil
+}
+
+// listParams sets the resource version and timeout of options on req. The
+// parameter codec doesn't convert list options for this group version.
+func listParams(req *rest.Request, options api.ListOptions) *rest.Request {
+	if options.ResourceVersion != "" {
+		req = req.Param("resourceVersion", options.ResourceVersion)
+	}
+	if options.TimeoutSeconds != nil {
+		req = req.Param("timeoutSeconds", strconv.FormatInt(*options.TimeoutSeconds, 10))
+	}
+	return req
+}
@@ -0,0 +1,312 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"github.com/roboll/kube-vault-controller/pkg/vault"
+	"k8s.io/client-go/pkg/api"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/labels"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_ingressClaims(t *testing.T) {
+	tests := []struct {
+		name string
+		tls  []kube.IngressTLS
+		want map[string]map[string]interface{}
+	}{
+		{
+			name: "single host",
+			tls:  []kube.IngressTLS{{Hosts: []string{"example.com"}, SecretName: "example-tls"}},
+			want: map[string]map[string]interface{}{
+				"example-tls": {"common_name": "example.com"},
+			},
+		},
+		{
+			name: "alt names",
+			tls:  []kube.IngressTLS{{Hosts: []string{"example.com", "www.example.com", "api.example.com"}, SecretName: "example-tls"}},
+			want: map[string]map[string]interface{}{
+				"example-tls": {"common_name": "example.com", "alt_names": "www.example.com,api.example.com"},
+			},
+		},
+		{
+			name: "entries with the same secret are merged",
+			tls: []kube.IngressTLS{
+				{Hosts: []string{"example.com"}, SecretName: "example-tls"},
+				{Hosts: []string{"example.com", "www.example.com"}, SecretName: "example-tls"},
+				{Hosts: []string{"example.org"}, SecretName: "other-tls"},
+			},
+			want: map[string]map[string]interface{}{
+				"example-tls": {"common_name": "example.com", "alt_names": "www.example.com"},
+				"other-tls":   {"common_name": "example.org"},
+			},
+		},
+		{
+			name: "entries without secret or hosts are skipped",
+			tls: []kube.IngressTLS{
+				{Hosts: []string{"example.com"}},
+				{SecretName: "example-tls"},
+			},
+			want: map[string]map[string]interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ingress := &kube.Ingress{
+				ObjectMeta: api.ObjectMeta{Name: "web", Namespace: "example", UID: "1234"},
+				Spec:       kube.IngressSpec{TLS: tt.tls},
+			}
+			claims := ingressClaims(ingress, "pki/issue/ingress")
+
+			got := map[string]map[string]interface{}{}
+			for _, claim := range claims {
+				got[claim.Name] = claim.Spec.Data
+				if claim.Namespace != "example" || claim.Spec.Type != v1.SecretTypeTLS || claim.Spec.Path != "pki/issue/ingress" || !claim.Spec.FullChain {
+					t.Errorf("ingressClaims() claim %s = %+v, want a tls claim for pki/issue/ingress in example", claim.Name, claim)
+				}
+				if !ownedByIngress(claim, "web") || claim.OwnerReferences[0].UID != "1234" {
+					t.Errorf("ingressClaims() claim %s not owned by the ingress: %+v", claim.Name, claim.ObjectMeta)
+				}
+				if claim.Labels[vault.ManagedByLabel] != vault.ManagedByValue {
+					t.Errorf("ingressClaims() claim %s labels = %v, want managed", claim.Name, claim.Labels)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ingressClaims() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ownedByIngress(t *testing.T) {
+	ingress := &kube.Ingress{ObjectMeta: api.ObjectMeta{Name: "web", Namespace: "example", UID: "1234"}}
+	owned := ingressClaim(ingress, "example-tls", "pki/issue/ingress")
+	unlabeled := ingressClaim(ingress, "example-tls", "pki/issue/ingress")
+	delete(unlabeled.Labels, IngressLabel)
+	unowned := ingressClaim(ingress, "example-tls", "pki/issue/ingress")
+	unowned.OwnerReferences = nil
+
+	tests := []struct {
+		name    string
+		claim   *kube.SecretClaim
+		ingress string
+		want    bool
+	}{
+		{name: "created for ingress", claim: owned, ingress: "web", want: true},
+		{name: "created for other ingress", claim: owned, ingress: "api", want: false},
+		{name: "without label", claim: unlabeled, ingress: "web", want: false},
+		{name: "without owner reference", claim: unowned, ingress: "web", want: false},
+		{name: "hand made claim", claim: &kube.SecretClaim{ObjectMeta: api.ObjectMeta{Name: "example-tls"}}, ingress: "web", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ownedByIngress(tt.claim, tt.ingress); got != tt.want {
+				t.Errorf("ownedByIngress() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeAPI serves the secret claims api, recording each request as its method
+// and claim name and the claims written.
+type fakeAPI struct {
+	*httptest.Server
+
+	lock     sync.Mutex
+	requests []string
+	written  map[string]*kube.SecretClaim
+	// status responds to requests with a status, e.g. "POST example-tls".
+	status map[string]int
+}
+
+func newFakeAPI(t *testing.T, status map[string]int) *fakeAPI {
+	api := &fakeAPI{written: map[string]*kube.SecretClaim{}, status: status}
+	api.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		name := path.Base(req.URL.Path)
+		claim := &kube.SecretClaim{}
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, claim); err != nil {
+				t.Errorf("fake api: %s", err.Error())
+			}
+			name = claim.Name
+		}
+		request := req.Method + " " + name
+
+		api.lock.Lock()
+		api.requests = append(api.requests, request)
+		if len(body) > 0 {
+			api.written[name] = claim
+		}
+		api.lock.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if status, ok := api.status[request]; ok {
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"kind":"Status","apiVersion":"v1","status":"Failure","reason":%q,"code":%d}`, statusReason(status), status)
+			return
+		}
+		if len(body) == 0 {
+			fmt.Fprint(w, `{"kind":"Status","apiVersion":"v1","status":"Success"}`)
+			return
+		}
+		if req.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		w.Write(body)
+	}))
+	return api
+}
+
+func statusReason(status int) string {
+	switch status {
+	case http.StatusConflict:
+		return "AlreadyExists"
+	case http.StatusNotFound:
+		return "NotFound"
+	}
+	return "InternalError"
+}
+
+func Test_syncIngress(t *testing.T) {
+	selector, err := labels.Parse("vaultproject.io/tls=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ingress := &kube.Ingress{
+		ObjectMeta: api.ObjectMeta{Name: "web", Namespace: "example", UID: "1234", Labels: map[string]string{"vaultproject.io/tls": "true"}},
+		Spec:       kube.IngressSpec{TLS: []kube.IngressTLS{{Hosts: []string{"example.com", "www.example.com"}, SecretName: "example-tls"}}},
+	}
+	unmatched := *ingress
+	unmatched.Labels = map[string]string{"vaultproject.io/tls": "false"}
+
+	current := ingressClaim(ingress, "example-tls", "pki/issue/ingress")
+	current.Spec.Data = map[string]interface{}{"common_name": "example.com", "alt_names": "www.example.com"}
+	outdated := ingressClaim(ingress, "example-tls", "pki/issue/ingress")
+	outdated.Spec.Data = map[string]interface{}{"common_name": "example.com"}
+	removed := ingressClaim(ingress, "old-tls", "pki/issue/ingress")
+	removed.Spec.Data = map[string]interface{}{"common_name": "old.example.com"}
+	handMade := &kube.SecretClaim{ObjectMeta: api.ObjectMeta{Name: "example-tls", Namespace: "example"}}
+	otherIngress := ingressClaim(&kube.Ingress{ObjectMeta: api.ObjectMeta{Name: "api", Namespace: "example"}}, "api-tls", "pki/issue/ingress")
+
+	tests := []struct {
+		name         string
+		ingress      *kube.Ingress
+		claims       []*kube.SecretClaim
+		status       map[string]int
+		wantRequests []string
+		wantErr      bool
+	}{
+		{
+			name:         "creates claim",
+			ingress:      ingress,
+			wantRequests: []string{"POST example-tls"},
+		},
+		{
+			name:         "claim exists but is not cached yet",
+			ingress:      ingress,
+			status:       map[string]int{"POST example-tls": http.StatusConflict},
+			wantRequests: []string{"POST example-tls"},
+		},
+		{
+			name:         "create fails",
+			ingress:      ingress,
+			status:       map[string]int{"POST example-tls": http.StatusInternalServerError},
+			wantRequests: []string{"POST example-tls"},
+			wantErr:      true,
+		},
+		{
+			name:    "claim up to date",
+			ingress: ingress,
+			claims:  []*kube.SecretClaim{current},
+		},
+		{
+			name:         "updates claim when hosts change",
+			ingress:      ingress,
+			claims:       []*kube.SecretClaim{outdated},
+			wantRequests: []string{"PUT example-tls"},
+		},
+		{
+			name:    "skips claim not created for the ingress",
+			ingress: ingress,
+			claims:  []*kube.SecretClaim{handMade},
+		},
+		{
+			name:         "deletes claim of removed tls entry",
+			ingress:      ingress,
+			claims:       []*kube.SecretClaim{current, removed, otherIngress},
+			wantRequests: []string{"DELETE old-tls"},
+		},
+		{
+			name:         "deletes claims of deleted ingress",
+			claims:       []*kube.SecretClaim{current, removed, otherIngress},
+			wantRequests: []string{"DELETE example-tls", "DELETE old-tls"},
+		},
+		{
+			name:         "deletes claims of ingress no longer matching the selector",
+			ingress:      &unmatched,
+			claims:       []*kube.SecretClaim{current, otherIngress},
+			wantRequests: []string{"DELETE example-tls"},
+		},
+		{
+			name:         "claim already deleted",
+			claims:       []*kube.SecretClaim{removed},
+			status:       map[string]int{"DELETE old-tls": http.StatusNotFound},
+			wantRequests: []string{"DELETE old-tls"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeAPI(t, tt.status)
+			defer server.Close()
+			claimClient, err := kube.NewSecretClaimClient(&rest.Config{Host: server.URL})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ingresses := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			if tt.ingress != nil {
+				ingresses.Add(tt.ingress)
+			}
+			claims := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			for _, claim := range tt.claims {
+				claims.Add(claim)
+			}
+			ctrl := &Controller{
+				claims:          claims,
+				claimClient:     claimClient,
+				ingresses:       ingresses,
+				ingressSelector: selector,
+				ingressPKIPath:  "pki/issue/ingress",
+			}
+
+			if err := ctrl.syncIngress("example/web"); (err != nil) != tt.wantErr {
+				t.Fatalf("syncIngress() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			sort.Strings(server.requests)
+			if !reflect.DeepEqual(server.requests, tt.wantRequests) {
+				t.Errorf("syncIngress() requests = %v, want %v", server.requests, tt.wantRequests)
+			}
+
+			written, ok := server.written["example-tls"]
+			if !ok || tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(written.Spec.Data, current.Spec.Data) || !ownedByIngress(written, "web") {
+				t.Errorf("syncIngress() wrote claim %+v, want %+v", written, current)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/metrics"
+	"github.com/roboll/kube-vault-controller/pkg/vault"
+
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var (
+	reconciles = metrics.DefaultRegistry.NewCounterVec(
+		"kube_vault_controller_reconcile_total",
+		"Claim syncs by namespace.",
+		"namespace",
+	)
+	reconcileErrors = metrics.DefaultRegistry.NewCounterVec(
+		"kube_vault_controller_reconcile_errors_total",
+		"Failed claim syncs by namespace.",
+		"namespace",
+	)
+)
+
+func observeReconcile(key string, err error) {
+	namespace, _, _ := cache.SplitMetaNamespaceKey(key)
+	reconciles.Inc(namespace)
+	if err != nil {
+		reconcileErrors.Inc(namespace)
+	}
+}
+
+// registerMetrics registers the gauges computed from the state of the
+// controller when scraped.
+func (ctrl *Controller) registerMetrics(registry *metrics.Registry) {
+	registry.NewGaugeVecFunc(
+		"kube_vault_controller_lease_remaining_seconds",
+		"Seconds until the lease of a claim's secret expires, from its certificate expiration annotation for TLS secrets and its lease expiration annotation otherwise.",
+		ctrl.leaseRemaining,
+		"namespace", "claim",
+	)
+	registry.NewGaugeFunc(
+		"kube_vault_controller_token_ttl_seconds",
+		"Seconds until the controller token expires, zero if it doesn't expire.",
+		func() float64 {
+			return ctrl.Token.TTL().Seconds()
+		},
+	)
+	registry.NewGaugeFunc(
+		"kube_vault_controller_queue_depth",
+		"Claims waiting to be synced.",
+		func() float64 {
+			return float64(ctrl.queue.Len())
+		},
+	)
+}
+
+// leaseRemaining returns the lease remaining of every managed secret. TLS
+// secrets expire with their certificate, which the lease of a pki secret may
+// outlive. KV version 2 secrets have no lease and are left out.
+func (ctrl *Controller) leaseRemaining() []metrics.Sample {
+	now := time.Now()
+	var samples []metrics.Sample
+	for _, obj := range ctrl.secrets.List() {
+		secret, ok := obj.(*v1.Secret)
+		if !ok || !vault.IsManaged(secret) {
+			continue
+		}
+		if _, ok := secret.Annotations[vault.KVVersionKey]; ok {
+			continue
+		}
+		annotation := vault.LeaseExpirationKey
+		if _, ok := secret.Annotations[vault.CertificateExpirationKey]; ok && secret.Type == v1.SecretTypeTLS {
+			annotation = vault.CertificateExpirationKey
+		}
+		expiration, err := strconv.ParseInt(secret.Annotations[annotation], 10, 64)
+		if err != nil {
+			continue
+		}
+		samples = append(samples, metrics.Sample{
+			LabelValues: []string{secret.Namespace, secret.Name},
+			Value:       time.Unix(expiration, 0).Sub(now).Seconds(),
+		})
+	}
+	return samples
+}
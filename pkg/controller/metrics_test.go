@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/vault"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_leaseRemaining(t *testing.T) {
+	in := func(d time.Duration) string {
+		return strconv.FormatInt(time.Now().Add(d).Unix(), 10)
+	}
+
+	tests := []struct {
+		name        string
+		secretType  v1.SecretType
+		labels      map[string]string
+		annotations map[string]string
+		want        time.Duration
+		wantSample  bool
+	}{
+		{
+			name:        "lease expiration",
+			secretType:  v1.SecretTypeOpaque,
+			annotations: map[string]string{vault.LeaseExpirationKey: in(time.Hour)},
+			want:        time.Hour,
+			wantSample:  true,
+		},
+		{
+			name:       "tls secret expires with its certificate",
+			secretType: v1.SecretTypeTLS,
+			annotations: map[string]string{
+				vault.LeaseExpirationKey:       in(30 * 24 * time.Hour),
+				vault.CertificateExpirationKey: in(time.Hour),
+			},
+			want:       time.Hour,
+			wantSample: true,
+		},
+		{
+			name:        "tls secret without certificate expiration",
+			secretType:  v1.SecretTypeTLS,
+			annotations: map[string]string{vault.LeaseExpirationKey: in(time.Hour)},
+			want:        time.Hour,
+			wantSample:  true,
+		},
+		{
+			name:        "kv version 2",
+			secretType:  v1.SecretTypeOpaque,
+			annotations: map[string]string{vault.KVVersionKey: "3"},
+		},
+		{
+			name:        "not managed",
+			secretType:  v1.SecretTypeOpaque,
+			labels:      map[string]string{},
+			annotations: map[string]string{vault.LeaseExpirationKey: in(time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := tt.labels
+			if labels == nil {
+				labels = map[string]string{vault.ManagedByLabel: vault.ManagedByValue}
+			}
+			secrets := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			secrets.Add(&v1.Secret{
+				ObjectMeta: v1.ObjectMeta{Name: "app", Namespace: "example", Labels: labels, Annotations: tt.annotations},
+				Type:       tt.secretType,
+			})
+			ctrl := &Controller{secrets: secrets}
+
+			samples := ctrl.leaseRemaining()
+			if (len(samples) == 1) != tt.wantSample {
+				t.Fatalf("leaseRemaining() = %v, wantSample %t", samples, tt.wantSample)
+			}
+			if !tt.wantSample {
+				return
+			}
+			if got := samples[0].Value; math.Abs(got-tt.want.Seconds()) > 2 {
+				t.Errorf("leaseRemaining() = %f, want %f", got, tt.want.Seconds())
+			}
+		})
+	}
+}
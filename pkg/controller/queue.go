@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"github.com/roboll/kube-vault-controller/pkg/queue"
+)
+
+const (
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 5 * time.Minute
+)
+
+// claimQueue queues secret claim keys for the workers. Along with each key it
+// remembers whether the next sync is forced, and the last known state of
+// claims that were deleted so their secret can be cleaned up.
+type claimQueue struct {
+	*queue.Queue
+
+	lock    sync.Mutex
+	forced  map[string]bool
+	deleted map[string]*kube.SecretClaim
+}
+
+func newClaimQueue() *claimQueue {
+	return &claimQueue{
+		Queue:   queue.New(queue.NewBackoff(retryBaseDelay, retryMaxDelay)),
+		forced:  map[string]bool{},
+		deleted: map[string]*kube.SecretClaim{},
+	}
+}
+
+// enqueue queues a sync of key. A forced sync stays forced until it is
+// processed, even if the key is queued again without force.
+func (q *claimQueue) enqueue(key string, force bool) {
+	if force {
+		q.setForced(key)
+	}
+	q.Add(key)
+}
+
+// enqueueDelete queues the cleanup of a deleted claim.
+func (q *claimQueue) enqueueDelete(key string, claim *kube.SecretClaim) {
+	q.lock.Lock()
+	q.deleted[key] = claim
+	q.lock.Unlock()
+
+	q.Add(key)
+}
+
+// enqueueOrphan queues the cleanup of a secret without a claim, unless the
+// cleanup of its deleted claim is already queued.
+func (q *claimQueue) enqueueOrphan(key string, claim *kube.SecretClaim) {
+	q.lock.Lock()
+	if _, ok := q.deleted[key]; !ok {
+		q.deleted[key] = claim
+	}
+	q.lock.Unlock()
+
+	q.Add(key)
+}
+
+func (q *claimQueue) setForced(key string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.forced[key] = true
+}
+
+// takeForced returns whether the sync of key is forced and resets it.
+func (q *claimQueue) takeForced(key string) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	force := q.forced[key]
+	delete(q.forced, key)
+	return force
+}
+
+// deletedClaim returns the last known state of a deleted claim, or nil.
+func (q *claimQueue) deletedClaim(key string) *kube.SecretClaim {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.deleted[key]
+}
+
+// forgetDeleted drops the deleted claim for key, if it is still claim.
+func (q *claimQueue) forgetDeleted(key string, claim *kube.SecretClaim) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if claim == nil || q.deleted[key] == claim {
+		delete(q.deleted, key)
+	}
+}
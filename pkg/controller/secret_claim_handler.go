@@ -5,13 +5,11 @@ import (
 	"reflect"
 
 	"github.com/roboll/kube-vault-controller/pkg/kube"
-	"k8s.io/client-go/pkg/api"
-	"k8s.io/client-go/pkg/runtime/serializer"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 )
 
-func newSecretClaimHandler(manager kube.SecretClaimManager) cache.ResourceEventHandlerFuncs {
+func newSecretClaimHandler(queue *claimQueue) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
 			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
@@ -21,16 +19,13 @@ func newSecretClaimHandler(manager kube.SecretClaimManager) cache.ResourceEventH
 			}
 
 			log.Printf("secret-claim-handler: %s: handling add for secret claim", key)
-			claim, ok := obj.(*kube.SecretClaim)
-			if !ok {
+			if _, ok := obj.(*kube.SecretClaim); !ok {
 				log.Printf("error: expected *kube.SecretClaim, got %s", reflect.TypeOf(obj))
 				return
 			}
 
 			log.Printf("secret-claim-handler: %s: scheduling create/update for secret (force=true)", key)
-			if err := manager.CreateOrUpdateSecret(claim, true); err != nil {
-				log.Printf("error: failed to update secret for key %s: %s", key, err.Error())
-			}
+			queue.enqueue(key, true)
 		},
 		UpdateFunc: func(old, obj interface{}) {
 			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
@@ -53,9 +48,7 @@ func newSecretClaimHandler(manager kube.SecretClaimManager) cache.ResourceEventH
 
 			force := !reflect.DeepEqual(claim.Spec, oldClaim.Spec)
 			log.Printf("secret-claim-handler: %s: scheduling create/update for secret (force=%t)", key, force)
-			if err := manager.CreateOrUpdateSecret(claim, force); err != nil {
-				log.Printf("error: failed to update secret for key %s: %s", key, err.Error())
-			}
+			queue.enqueue(key, force)
 		},
 		DeleteFunc: func(obj interface{}) {
 			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
@@ -65,6 +58,9 @@ func newSecretClaimHandler(manager kube.SecretClaimManager) cache.ResourceEventH
 			}
 
 			log.Printf("secret-claim-handler: %s: handling delete for secret claim", key)
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
 			claim, ok := obj.(*kube.SecretClaim)
 			if !ok {
 				log.Printf("error: expected *kube.SecretClaim, got %s", reflect.TypeOf(obj))
@@ -72,25 +68,14 @@ func newSecretClaimHandler(manager kube.SecretClaimManager) cache.ResourceEventH
 			}
 
 			log.Printf("secret-claim-handler: %s: scheduling delete for secret", key)
-			if err := manager.DeleteSecret(claim); err != nil {
-				log.Printf("error: failed to delete secret for key %s: %s", key, err.Error())
-			}
+			queue.enqueueDelete(key, claim)
 		},
 	}
 }
 
 // newClaimSource returns a cache.ListerWatcher for secret claim objects.
 func newSecretClaimSource(config *rest.Config, namespace string) (cache.ListerWatcher, error) {
-	configCopy := *config
-	if configCopy.UserAgent == "" {
-		configCopy.UserAgent = rest.DefaultKubernetesUserAgent()
-	}
-
-	configCopy.APIPath = "/apis"
-	configCopy.GroupVersion = &kube.GroupVersion
-	configCopy.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: api.Codecs}
-
-	client, err := rest.RESTClientFor(&configCopy)
+	client, err := kube.NewSecretClaimClient(config)
 	if err != nil {
 		return nil, err
 	}
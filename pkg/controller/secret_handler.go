@@ -11,18 +11,18 @@ import (
 	"k8s.io/client-go/tools/cache"
 )
 
-func newSecretHandler(manager kube.SecretClaimManager, claims cache.Store) cache.ResourceEventHandlerFuncs {
+func newSecretHandler(queue *claimQueue, claims cache.Store) cache.ResourceEventHandlerFuncs {
 	return cache.ResourceEventHandlerFuncs{
 		UpdateFunc: func(old, obj interface{}) {
-			handleSecretOp(manager, claims, obj, "update")
+			handleSecretOp(queue, claims, obj, "update")
 		},
 		DeleteFunc: func(obj interface{}) {
-			handleSecretOp(manager, claims, obj, "delete")
+			handleSecretOp(queue, claims, obj, "delete")
 		},
 	}
 }
 
-func handleSecretOp(manager kube.SecretClaimManager, claims cache.Store, obj interface{}, op string) {
+func handleSecretOp(queue *claimQueue, claims cache.Store, obj interface{}, op string) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 	if err != nil {
 		panic(err)
@@ -39,15 +39,12 @@ func handleSecretOp(manager kube.SecretClaimManager, claims cache.Store, obj int
 		return
 	}
 
-	claim, ok := raw.(*kube.SecretClaim)
-	if !ok {
-		log.Printf("error: expected *kube.SecretClaim, got %s", reflect.TypeOf(obj))
+	if _, ok := raw.(*kube.SecretClaim); !ok {
+		log.Printf("error: expected *kube.SecretClaim, got %s", reflect.TypeOf(raw))
 		return
 	}
 	log.Printf("secret-handler: %s: requesting secret create/update (force=false)", key)
-	if err := manager.CreateOrUpdateSecret(claim, false); err != nil {
-		log.Printf("error: failed to create/update secret %s: %s", key, err.Error())
-	}
+	queue.enqueue(key, false)
 }
 
 // newSecretSoruce returns a cache.ListerWatcher for secret objects.
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"log"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"github.com/roboll/kube-vault-controller/pkg/vault"
+
+	"k8s.io/client-go/pkg/api"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// sweepOrphans finds managed secrets owned by a claim that no longer exists,
+// left over from a claim deletion the controller missed, and queues their
+// cleanup. Secrets that are only labeled as managed are left alone, as anyone
+// who can create a secret can label it. The lease of an orphan is revoked with
+// the controller token, as the claim's auth settings are gone, and only if it
+// is in a path claims in its namespace may read.
+func (ctrl *Controller) sweepOrphans() {
+	if !ctrl.SecretController.HasSynced() || !ctrl.SecretClaimController.HasSynced() {
+		log.Printf("orphan-sweep: skipping sweep, informers not synced")
+		return
+	}
+
+	orphans := 0
+	for _, obj := range ctrl.secrets.List() {
+		secret, ok := obj.(*v1.Secret)
+		if !ok || !vault.IsManaged(secret) || vault.ClaimOwner(secret) == nil {
+			continue
+		}
+
+		key, err := cache.MetaNamespaceKeyFunc(secret)
+		if err != nil {
+			continue
+		}
+		if _, exists, err := ctrl.claims.GetByKey(key); err != nil || exists {
+			continue
+		}
+
+		orphans++
+		if ctrl.sweepDryRun {
+			log.Printf("orphan-sweep: %s: secret has no claim, would revoke its lease and delete it (dry run)", key)
+			continue
+		}
+		log.Printf("orphan-sweep: %s: secret has no claim, scheduling revoke and delete", key)
+		ctrl.queue.enqueueOrphan(key, &kube.SecretClaim{
+			ObjectMeta: api.ObjectMeta{
+				Name:      secret.Name,
+				Namespace: secret.Namespace,
+			},
+		})
+	}
+	log.Printf("orphan-sweep: found %d orphaned secrets", orphans)
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"github.com/roboll/kube-vault-controller/pkg/vault"
+	"k8s.io/client-go/pkg/api"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_sweepOrphans(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	synced := newInformer(t, stop)
+
+	managed := map[string]string{vault.ManagedByLabel: vault.ManagedByValue}
+	owners := []v1.OwnerReference{{APIVersion: kube.APIGroupVersion, Kind: "SecretClaim", Name: "app", UID: "1234"}}
+	tests := []struct {
+		name       string
+		labels     map[string]string
+		owners     []v1.OwnerReference
+		claimed    bool
+		dryRun     bool
+		wantQueued bool
+	}{
+		{name: "managed secret without claim", labels: managed, owners: owners, wantQueued: true},
+		{name: "managed secret with claim", labels: managed, owners: owners, claimed: true},
+		{name: "managed secret in dry run", labels: managed, owners: owners, dryRun: true},
+		{name: "managed label only", labels: managed},
+		{name: "managed label and other owner", labels: managed, owners: []v1.OwnerReference{{APIVersion: "v1", Kind: "ServiceAccount", Name: "app"}}},
+		{name: "owner reference without managed label", owners: owners},
+		{name: "unmanaged secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			secrets.Add(&v1.Secret{ObjectMeta: v1.ObjectMeta{
+				Name:            "app",
+				Namespace:       "example",
+				Labels:          tt.labels,
+				Annotations:     map[string]string{vault.LeaseIDKey: "database/creds/app/1"},
+				OwnerReferences: tt.owners,
+			}})
+			claims := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			if tt.claimed {
+				claims.Add(&kube.SecretClaim{ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example"}})
+			}
+			ctrl := &Controller{
+				SecretController:      synced,
+				SecretClaimController: synced,
+				secrets:               secrets,
+				claims:                claims,
+				queue:                 newClaimQueue(),
+				sweepDryRun:           tt.dryRun,
+			}
+
+			ctrl.sweepOrphans()
+			queued := ctrl.queue.Len() == 1
+			if queued != tt.wantQueued {
+				t.Errorf("sweepOrphans() queued = %t, want %t", queued, tt.wantQueued)
+			}
+			if orphan := ctrl.queue.deletedClaim("example/app"); (orphan != nil) != tt.wantQueued {
+				t.Errorf("sweepOrphans() stand-in claim = %v, want queued %t", orphan, tt.wantQueued)
+			} else if orphan != nil && (orphan.UID != "" || orphan.Spec.ServiceAccountName != "" || orphan.Spec.AppRoleSecretName != "") {
+				t.Errorf("sweepOrphans() stand-in claim = %+v, want no uid or auth", orphan)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"reflect"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+)
+
+// syncJitter is the fraction a scheduled sync is moved forward by at most.
+const syncJitter = 0.1
+
+// runWorker syncs claims from the queue until it is shut down.
+func (ctrl *Controller) runWorker() {
+	for ctrl.processNext() {
+	}
+}
+
+func (ctrl *Controller) processNext() bool {
+	key, shutdown := ctrl.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer ctrl.queue.Done(key)
+
+	after, err := ctrl.sync(key)
+	ctrl.markProgress()
+	observeReconcile(key, err)
+	if err == nil {
+		ctrl.queue.Forget(key)
+		if after > 0 {
+			after = jitter(after)
+			log.Printf("worker: %s: scheduling sync in %s", key, after)
+			ctrl.queue.AddAfter(key, after)
+		}
+		return true
+	}
+
+	if ctrl.queue.NumRequeues(key) < ctrl.maxRetries {
+		log.Printf("error: failed to sync %s, retrying: %s", key, err.Error())
+		ctrl.queue.AddRateLimited(key)
+		return true
+	}
+
+	log.Printf("error: failed to sync %s, dropping after %d retries: %s", key, ctrl.maxRetries, err.Error())
+	ctrl.queue.Forget(key)
+	ctrl.queue.forgetDeleted(key, nil)
+	return true
+}
+
+// sync brings the secret for key in line with its claim, or cleans it up if
+// the claim was deleted. It returns how long until key should be synced
+// again, or zero.
+func (ctrl *Controller) sync(key string) (time.Duration, error) {
+	force := ctrl.queue.takeForced(key)
+
+	obj, exists, err := ctrl.claims.GetByKey(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if !exists {
+		claim := ctrl.queue.deletedClaim(key)
+		if claim == nil {
+			log.Printf("worker: %s: skipping sync, no claim found", key)
+			return 0, nil
+		}
+		if err := ctrl.manager.DeleteSecret(claim); err != nil {
+			return 0, err
+		}
+		ctrl.queue.forgetDeleted(key, claim)
+		return 0, nil
+	}
+
+	// the claim was recreated before the deleted one was cleaned up, its
+	// secret is replaced instead.
+	ctrl.queue.forgetDeleted(key, nil)
+
+	claim, ok := obj.(*kube.SecretClaim)
+	if !ok {
+		return 0, fmt.Errorf("expected *kube.SecretClaim, got %s", reflect.TypeOf(obj))
+	}
+	after, err := ctrl.manager.CreateOrUpdateSecret(claim, force)
+	if err != nil && force {
+		ctrl.queue.setForced(key)
+	}
+	return after, err
+}
+
+// jitter shortens d by up to syncJitter, so claims created together don't
+// all renew at once and no claim is synced after its renew buffer started.
+func jitter(d time.Duration) time.Duration {
+	return d - time.Duration(rand.Float64()*syncJitter*float64(d))
+}
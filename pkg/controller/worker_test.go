@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"github.com/roboll/kube-vault-controller/pkg/queue"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_processNext(t *testing.T) {
+	syncErr := errors.New("sync failed")
+	tests := []struct {
+		name string
+		// claimed is whether the claim is in the informer.
+		claimed bool
+		// deleted is whether the cleanup of the deleted claim is queued.
+		deleted     bool
+		force       bool
+		syncErr     error
+		deleteErr   error
+		maxRetries  int
+		wantForced  []bool
+		wantDeletes int
+	}{
+		{name: "sync succeeds", claimed: true, force: true, wantForced: []bool{true}},
+		{name: "retries stop after max retries", claimed: true, syncErr: syncErr, maxRetries: 2, wantForced: []bool{false, false, false}},
+		{name: "failed forced sync stays forced", claimed: true, force: true, syncErr: syncErr, maxRetries: 2, wantForced: []bool{true, true, true}},
+		{name: "deleted claim is cleaned up", deleted: true, wantDeletes: 1},
+		{name: "failed cleanup is retried until max retries", deleted: true, deleteErr: syncErr, maxRetries: 2, wantDeletes: 3},
+		{name: "recreated claim replaces the cleanup", claimed: true, deleted: true, wantForced: []bool{false}},
+		{name: "no claim"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := &fakeManager{syncErr: tt.syncErr, deleteErr: tt.deleteErr}
+			claims := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			claim := &kube.SecretClaim{ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example"}}
+			if tt.claimed {
+				claims.Add(claim)
+			}
+			ctrl := &Controller{
+				manager:    manager,
+				claims:     claims,
+				maxRetries: tt.maxRetries,
+				queue: &claimQueue{
+					Queue:   queue.New(queue.NewBackoff(time.Millisecond, time.Millisecond)),
+					forced:  map[string]bool{},
+					deleted: map[string]*kube.SecretClaim{},
+				},
+			}
+			defer ctrl.queue.ShutDown()
+
+			if tt.deleted {
+				ctrl.queue.enqueueDelete("example/app", claim)
+			}
+			ctrl.queue.enqueue("example/app", tt.force)
+			go ctrl.runWorker()
+
+			// wait for the expected calls, then long enough for any retry.
+			for i := 0; i < 100; i++ {
+				if forced, deletes := manager.calls(); len(forced)+deletes >= len(tt.wantForced)+tt.wantDeletes {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			time.Sleep(50 * time.Millisecond)
+
+			forced, deletes := manager.calls()
+			if !reflect.DeepEqual(forced, tt.wantForced) {
+				t.Errorf("processNext() synced with force %v, want %v", forced, tt.wantForced)
+			}
+			if deletes != tt.wantDeletes {
+				t.Errorf("processNext() deleted %d times, want %d", deletes, tt.wantDeletes)
+			}
+			if got := ctrl.queue.NumRequeues("example/app"); got != 0 {
+				t.Errorf("NumRequeues() = %d, want 0 once dropped", got)
+			}
+			if ctrl.queue.deletedClaim("example/app") != nil {
+				t.Error("deletedClaim() kept the deleted claim after it was cleaned up or dropped")
+			}
+			// a dropped forced sync stays forced for the next change or
+			// sync period.
+			if got, want := ctrl.queue.takeForced("example/app"), tt.force && tt.syncErr != nil; got != want {
+				t.Errorf("takeForced() = %t, want %t", got, want)
+			}
+		})
+	}
+}
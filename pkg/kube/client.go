@@ -0,0 +1,36 @@
+package kube
+
+import (
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/runtime/serializer"
+	"k8s.io/client-go/rest"
+)
+
+// NewSecretClaimClient returns a rest client for secret claim objects.
+func NewSecretClaimClient(config *rest.Config) (*rest.RESTClient, error) {
+	configCopy := *config
+	if configCopy.UserAgent == "" {
+		configCopy.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	configCopy.APIPath = "/apis"
+	configCopy.GroupVersion = &GroupVersion
+	configCopy.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: api.Codecs}
+
+	return rest.RESTClientFor(&configCopy)
+}
+
+// NewIngressClient returns a rest client for networking.k8s.io/v1 ingress
+// objects.
+func NewIngressClient(config *rest.Config) (*rest.RESTClient, error) {
+	configCopy := *config
+	if configCopy.UserAgent == "" {
+		configCopy.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	configCopy.APIPath = "/apis"
+	configCopy.GroupVersion = &IngressGroupVersion
+	configCopy.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: api.Codecs}
+
+	return rest.RESTClientFor(&configCopy)
+}
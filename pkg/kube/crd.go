@@ -0,0 +1,330 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/unversioned"
+	"k8s.io/client-go/pkg/runtime/serializer"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	CustomResourceDefinitionName = ResourceSecretClaims + "." + APIGroup
+
+	customResourceDefinitionsPath       = "/apis/apiextensions.k8s.io/v1/customresourcedefinitions"
+	legacyCustomResourceDefinitionsPath = "/apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions"
+	establishTimeout                    = time.Minute
+)
+
+// legacyCustomResourceDefinition is the secret claim custom resource
+// definition for clusters that only serve apiextensions.k8s.io/v1beta1, the
+// ones that still have the third party resource. It has no schema, apply
+// CustomResourceDefinition once the cluster serves apiextensions.k8s.io/v1.
+const legacyCustomResourceDefinition = `apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: secretclaims.vaultproject.io
+spec:
+  group: vaultproject.io
+  version: v1
+  scope: Namespaced
+  names:
+    kind: SecretClaim
+    listKind: SecretClaimList
+    plural: secretclaims
+    singular: secretclaim
+`
+
+// CustomResourceDefinition is the secret claim custom resource definition.
+const CustomResourceDefinition = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: secretclaims.vaultproject.io
+spec:
+  group: vaultproject.io
+  scope: Namespaced
+  names:
+    kind: SecretClaim
+    listKind: SecretClaimList
+    plural: secretclaims
+    singular: secretclaim
+  versions:
+    - name: v1
+      served: true
+      storage: true
+      schema:
+        openAPIV3Schema:
+          type: object
+          properties:
+            spec:
+              type: object
+              required:
+                - path
+              properties:
+                type:
+                  type: string
+                path:
+                  type: string
+                  minLength: 1
+                data:
+                  type: object
+                  nullable: true
+                  x-kubernetes-preserve-unknown-fields: true
+                renew:
+                  type: integer
+                  minimum: 0
+                annotations:
+                  type: object
+                  nullable: true
+                  additionalProperties:
+                    type: string
+                version:
+                  type: integer
+                  minimum: 0
+                template:
+                  type: object
+                  additionalProperties:
+                    type: string
+                flatten:
+                  type: boolean
+                fullChain:
+                  type: boolean
+                privateKey:
+                  type: object
+                  properties:
+                    algorithm:
+                      type: string
+                      enum:
+                        - RSA
+                        - ECDSA
+                    size:
+                      type: integer
+                      minimum: 0
+                    rotationPolicy:
+                      type: string
+                      enum:
+                        - Never
+                        - Always
+                renewBeforePercent:
+                  type: integer
+                  minimum: 1
+                  maximum: 99
+                serviceAccountName:
+                  type: string
+                vaultRole:
+                  type: string
+                appRoleSecretName:
+                  type: string
+                skipRevoke:
+                  type: boolean
+                adoptExisting:
+                  type: boolean
+            status:
+              type: object
+              properties:
+                observedGeneration:
+                  type: integer
+                conditions:
+                  type: array
+                  items:
+                    type: object
+                    required:
+                      - type
+                      - status
+                    properties:
+                      type:
+                        type: string
+                      status:
+                        type: string
+                      lastTransitionTime:
+                        type: string
+                        format: date-time
+                      reason:
+                        type: string
+                      message:
+                        type: string
+                leaseExpiration:
+                  type: string
+                  format: date-time
+                lastRenewTime:
+                  type: string
+                  format: date-time
+                lastRotationTime:
+                  type: string
+                  format: date-time
+                lastError:
+                  type: string
+      subresources:
+        status: {}
+      additionalPrinterColumns:
+        - name: Type
+          type: string
+          jsonPath: .spec.type
+        - name: Path
+          type: string
+          jsonPath: .spec.path
+        - name: Ready
+          type: string
+          jsonPath: .status.conditions[?(@.type=="Ready")].status
+        - name: Lease Expiration
+          type: date
+          jsonPath: .status.leaseExpiration
+        - name: Age
+          type: date
+          jsonPath: .metadata.creationTimestamp
+`
+
+type customResourceDefinition struct {
+	Spec struct {
+		Versions []struct {
+			Name   string `json:"name"`
+			Served bool   `json:"served"`
+		} `json:"versions"`
+	} `json:"spec"`
+	Status struct {
+		Conditions []struct {
+			Type    string `json:"type"`
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"conditions"`
+	} `json:"status"`
+}
+
+// EnsureCustomResourceDefinition verifies the secret claim custom resource
+// definition serves this version and is established, creating it first if it
+// does not exist and install is set.
+func EnsureCustomResourceDefinition(config *rest.Config, install bool) error {
+	client, err := newAPIExtensionsClient(config, "v1")
+	if err != nil {
+		return err
+	}
+
+	crd, err := getCustomResourceDefinition(client, customResourceDefinitionsPath)
+	if err != nil {
+		return err
+	}
+	if crd == nil {
+		if !install {
+			return fmt.Errorf("custom resource definition %s does not exist", CustomResourceDefinitionName)
+		}
+		if err := createCustomResourceDefinition(client, customResourceDefinitionsPath, CustomResourceDefinition); err != nil {
+			return err
+		}
+	}
+	return waitEstablished(client, customResourceDefinitionsPath, true)
+}
+
+// ensureLegacyCustomResourceDefinition creates the apiextensions.k8s.io/v1beta1
+// custom resource definition if it does not exist, and waits for it to be
+// established.
+func ensureLegacyCustomResourceDefinition(config *rest.Config) error {
+	client, err := newAPIExtensionsClient(config, "v1beta1")
+	if err != nil {
+		return err
+	}
+
+	crd, err := getCustomResourceDefinition(client, legacyCustomResourceDefinitionsPath)
+	if err != nil {
+		return err
+	}
+	if crd == nil {
+		if err := createCustomResourceDefinition(client, legacyCustomResourceDefinitionsPath, legacyCustomResourceDefinition); err != nil {
+			return err
+		}
+	}
+	// v1beta1 definitions have a single version field, not versions.
+	return waitEstablished(client, legacyCustomResourceDefinitionsPath, false)
+}
+
+func createCustomResourceDefinition(client *rest.RESTClient, path, definition string) error {
+	log.Printf("crd: creating custom resource definition %s", CustomResourceDefinitionName)
+	body, err := yaml.YAMLToJSON([]byte(definition))
+	if err != nil {
+		return err
+	}
+	if err := client.Post().AbsPath(path).SetHeader("Content-Type", "application/json").Body(body).Do().Error(); err != nil {
+		return fmt.Errorf("failed to create custom resource definition %s: %s", CustomResourceDefinitionName, err.Error())
+	}
+	return nil
+}
+
+func waitEstablished(client *rest.RESTClient, path string, verify bool) error {
+	deadline := time.Now().Add(establishTimeout)
+	for {
+		crd, err := getCustomResourceDefinition(client, path)
+		if err != nil {
+			return err
+		}
+		if crd != nil {
+			if verify {
+				if err := crd.verify(); err != nil {
+					return err
+				}
+			}
+			if crd.established() {
+				log.Printf("crd: custom resource definition %s is established", CustomResourceDefinitionName)
+				return nil
+			}
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("custom resource definition %s was not established within %s", CustomResourceDefinitionName, establishTimeout)
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func (crd *customResourceDefinition) verify() error {
+	for _, version := range crd.Spec.Versions {
+		if version.Name == APIVersion && version.Served {
+			return nil
+		}
+	}
+	return fmt.Errorf("custom resource definition %s does not serve version %s", CustomResourceDefinitionName, APIVersion)
+}
+
+func (crd *customResourceDefinition) established() bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == "Established" {
+			return condition.Status == "True"
+		}
+	}
+	return false
+}
+
+// getCustomResourceDefinition returns nil if the definition does not exist.
+func getCustomResourceDefinition(client *rest.RESTClient, path string) (*customResourceDefinition, error) {
+	var status int
+	raw, err := client.Get().AbsPath(path, CustomResourceDefinitionName).Do().StatusCode(&status).Raw()
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom resource definition %s: %s", CustomResourceDefinitionName, err.Error())
+	}
+
+	crd := &customResourceDefinition{}
+	if err := json.Unmarshal(raw, crd); err != nil {
+		return nil, err
+	}
+	return crd, nil
+}
+
+func newAPIExtensionsClient(config *rest.Config, version string) (*rest.RESTClient, error) {
+	configCopy := *config
+	if configCopy.UserAgent == "" {
+		configCopy.UserAgent = rest.DefaultKubernetesUserAgent()
+	}
+
+	configCopy.APIPath = "/apis"
+	configCopy.GroupVersion = &unversioned.GroupVersion{Group: "apiextensions.k8s.io", Version: version}
+	configCopy.NegotiatedSerializer = serializer.DirectCodecFactory{CodecFactory: api.Codecs}
+
+	return rest.RESTClientFor(&configCopy)
+}
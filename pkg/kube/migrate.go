@@ -0,0 +1,107 @@
+package kube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	"k8s.io/client-go/kubernetes"
+	kerrors "k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/unversioned"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/rest"
+)
+
+// ThirdPartyResourceName is the name of the legacy secret claim third party
+// resource, replaced by the custom resource definition.
+const ThirdPartyResourceName = "secret-claim." + APIGroup
+
+// MigrateThirdPartyResource moves secret claims stored by the legacy third
+// party resource to a custom resource definition. Claims are written to
+// backupFile, the third party resource (and with it the claims) is deleted,
+// the apiextensions.k8s.io/v1beta1 custom resource definition is installed and
+// the claims are recreated. It does nothing if the third party resource does
+// not exist.
+//
+// Third party resources are only served by clusters that don't serve
+// apiextensions.k8s.io/v1 yet, so the definition has no schema. Apply
+// CustomResourceDefinition once the cluster is upgraded.
+//
+// Controllers watching the third party resource must be stopped first, or
+// they will revoke the leases of the claims as they are deleted.
+func MigrateThirdPartyResource(config *rest.Config, backupFile string) error {
+	if backupFile == "" {
+		return errors.New("a backup file is required to migrate claims")
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	_, err = clientset.Extensions().ThirdPartyResources().Get(ThirdPartyResourceName)
+	if kerrors.IsNotFound(err) {
+		log.Printf("migrate: no third party resource %s, nothing to migrate", ThirdPartyResourceName)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get third party resource %s: %s", ThirdPartyResourceName, err.Error())
+	}
+
+	client, err := NewSecretClaimClient(config)
+	if err != nil {
+		return err
+	}
+
+	claims := &SecretClaimList{}
+	if err := client.Get().Resource(ResourceSecretClaims).Do().Into(claims); err != nil {
+		return fmt.Errorf("failed to list third party resource claims: %s", err.Error())
+	}
+
+	// the backup can be recreated with kubectl create -f.
+	claims.TypeMeta = unversioned.TypeMeta{Kind: "List", APIVersion: "v1"}
+	claims.ResourceVersion = ""
+	claims.SelfLink = ""
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		claim.TypeMeta = unversioned.TypeMeta{Kind: "SecretClaim", APIVersion: APIGroupVersion}
+		claim.ResourceVersion = ""
+		claim.UID = ""
+		claim.SelfLink = ""
+		claim.CreationTimestamp = unversioned.Time{}
+	}
+
+	backup, err := json.MarshalIndent(claims, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(backupFile, backup, 0600); err != nil {
+		return fmt.Errorf("failed to write backup of %d claims: %s", len(claims.Items), err.Error())
+	}
+	log.Printf("migrate: backed up %d claims to %s", len(claims.Items), backupFile)
+
+	if err := clientset.Extensions().ThirdPartyResources().Delete(ThirdPartyResourceName, &v1.DeleteOptions{}); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete third party resource %s: %s", ThirdPartyResourceName, err.Error())
+	}
+	log.Printf("migrate: deleted third party resource %s", ThirdPartyResourceName)
+
+	if err := ensureLegacyCustomResourceDefinition(config); err != nil {
+		return err
+	}
+
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		err := client.Post().Namespace(claim.Namespace).Resource(ResourceSecretClaims).Body(claim).Do().Error()
+		if kerrors.IsAlreadyExists(err) {
+			log.Printf("migrate: %s/%s: already exists", claim.Namespace, claim.Name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to recreate claim %s/%s, backup is in %s: %s", claim.Namespace, claim.Name, backupFile, err.Error())
+		}
+		log.Printf("migrate: %s/%s: recreated", claim.Namespace, claim.Name)
+	}
+	return nil
+}
@@ -2,6 +2,8 @@
 package kube
 
 import (
+	"time"
+
 	"k8s.io/client-go/pkg/api"
 	"k8s.io/client-go/pkg/api/unversioned"
 	v1 "k8s.io/client-go/pkg/api/v1"
@@ -13,6 +15,7 @@ const (
 	APIGroupVersion = APIGroup + "/" + APIVersion
 
 	ResourceSecretClaims = "secretclaims"
+	ResourceIngresses    = "ingresses"
 )
 
 var (
@@ -20,6 +23,12 @@ var (
 		Group:   APIGroup,
 		Version: APIVersion,
 	}
+
+	// IngressGroupVersion is the group version ingresses are read from.
+	IngressGroupVersion = unversioned.GroupVersion{
+		Group:   "networking.k8s.io",
+		Version: "v1",
+	}
 )
 
 type SecretSpec struct {
@@ -28,13 +37,104 @@ type SecretSpec struct {
 	Data        map[string]interface{} `json:"data"`
 	Renew       int64                  `json:"renew"`
 	Annotations map[string]string      `json:"annotations"`
+
+	// Version pins the version read from a kv version 2 mount, defaults to
+	// the latest version.
+	Version int `json:"version,omitempty"`
+	// Template maps secret keys to go templates rendered against the vault
+	// response, in addition to the keys copied from the response data.
+	Template map[string]string `json:"template,omitempty"`
+	// Flatten encodes nested maps in the vault response as keys joined with
+	// dots, instead of as json.
+	Flatten bool `json:"flatten,omitempty"`
+	// FullChain appends the CA chain to the certificate of a
+	// kubernetes.io/tls secret.
+	FullChain bool `json:"fullChain,omitempty"`
+	// PrivateKey, if set, generates the private key of a kubernetes.io/tls
+	// secret in the controller and has vault sign a certificate request for
+	// it at path, a pki sign endpoint, so the key never leaves the cluster.
+	PrivateKey *PrivateKeySpec `json:"privateKey,omitempty"`
+	// RenewBeforePercent rotates the certificate of a kubernetes.io/tls
+	// secret when less than this percentage of its lifetime is left, instead
+	// of renew seconds before it expires.
+	RenewBeforePercent int `json:"renewBeforePercent,omitempty"`
+
+	// ServiceAccountName, if set, authenticates to vault with the kubernetes
+	// auth method as this service account instead of using the controller token.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// VaultRole is the kubernetes auth role to login with, defaults to the
+	// claim namespace.
+	VaultRole string `json:"vaultRole,omitempty"`
+	// AppRoleSecretName, if set, authenticates to vault with the approle auth
+	// method using the role_id and secret_id keys of this secret.
+	AppRoleSecretName string `json:"appRoleSecretName,omitempty"`
+
+	// SkipRevoke leaves the lease unrevoked when the claim is deleted, for
+	// secrets shared with other consumers.
+	SkipRevoke bool `json:"skipRevoke,omitempty"`
+	// AdoptExisting takes over an existing secret the controller doesn't
+	// manage, after backing it up, instead of reporting a conflict.
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+}
+
+// Private key algorithms.
+const (
+	PrivateKeyRSA   = "RSA"
+	PrivateKeyECDSA = "ECDSA"
+)
+
+// Private key rotation policies.
+const (
+	// RotateNever reuses the key of the existing secret for new certificates.
+	RotateNever = "Never"
+	// RotateAlways generates a new key for each certificate.
+	RotateAlways = "Always"
+)
+
+// PrivateKeySpec is how the controller generates private keys.
+type PrivateKeySpec struct {
+	// Algorithm is RSA or ECDSA, defaults to RSA.
+	Algorithm string `json:"algorithm,omitempty"`
+	// Size is the size of RSA keys in bits, defaults to 2048, or the curve of
+	// ECDSA keys, one of 256, 384 or 521, defaults to 256.
+	Size int `json:"size,omitempty"`
+	// RotationPolicy is Never or Always, defaults to Never.
+	RotationPolicy string `json:"rotationPolicy,omitempty"`
+}
+
+type SecretClaimConditionType string
+
+const (
+	// SecretClaimReady is true when the secret exists and its lease has not
+	// expired.
+	SecretClaimReady SecretClaimConditionType = "Ready"
+	// SecretClaimSynced is true when the last sync with vault succeeded.
+	SecretClaimSynced SecretClaimConditionType = "Synced"
+)
+
+type SecretClaimCondition struct {
+	Type               SecretClaimConditionType `json:"type"`
+	Status             v1.ConditionStatus       `json:"status"`
+	LastTransitionTime unversioned.Time         `json:"lastTransitionTime,omitempty"`
+	Reason             string                   `json:"reason,omitempty"`
+	Message            string                   `json:"message,omitempty"`
+}
+
+type SecretClaimStatus struct {
+	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
+	Conditions         []SecretClaimCondition `json:"conditions,omitempty"`
+	LeaseExpiration    *unversioned.Time      `json:"leaseExpiration,omitempty"`
+	LastRenewTime      *unversioned.Time      `json:"lastRenewTime,omitempty"`
+	LastRotationTime   *unversioned.Time      `json:"lastRotationTime,omitempty"`
+	LastError          string                 `json:"lastError,omitempty"`
 }
 
 type SecretClaim struct {
 	unversioned.TypeMeta `json:",inline"`
 	api.ObjectMeta       `json:"metadata,omitempty"`
 
-	Spec SecretSpec `json:"spec"`
+	Spec   SecretSpec        `json:"spec"`
+	Status SecretClaimStatus `json:"status,omitempty"`
 }
 
 type SecretClaimList struct {
@@ -45,6 +145,38 @@ type SecretClaimList struct {
 }
 
 type SecretClaimManager interface {
-	CreateOrUpdateSecret(claim *SecretClaim, force bool) error
+	// CreateOrUpdateSecret syncs the secret for a claim, returning how long
+	// until it should be synced again, or zero if only changes or the sync
+	// period need to sync it.
+	CreateOrUpdateSecret(claim *SecretClaim, force bool) (time.Duration, error)
 	DeleteSecret(claim *SecretClaim) error
+	// RevokeIssued revokes leases vault issued that were not stored in a
+	// secret yet, once syncs in flight finished or the shutdown timeout
+	// passed. Syncs still in flight revoke the leases they are issued after
+	// it.
+	RevokeIssued()
+}
+
+// Ingress is the part of a networking.k8s.io/v1 ingress the controller reads.
+type Ingress struct {
+	unversioned.TypeMeta `json:",inline"`
+	api.ObjectMeta       `json:"metadata,omitempty"`
+
+	Spec IngressSpec `json:"spec"`
+}
+
+type IngressSpec struct {
+	TLS []IngressTLS `json:"tls,omitempty"`
+}
+
+type IngressTLS struct {
+	Hosts      []string `json:"hosts,omitempty"`
+	SecretName string   `json:"secretName,omitempty"`
+}
+
+type IngressList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+
+	Items []Ingress `json:"items"`
 }
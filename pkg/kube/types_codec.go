@@ -49,7 +49,7 @@ func init() {
 	}
 	if false { // reference the types, but skip this branch at build/run time
 		var v0 pkg3_api.ObjectMeta
-		var v1 pkg2_unversioned.TypeMeta
+		var v1 pkg2_unversioned.Time
 		var v2 pkg1_v1.SecretType
 		var v3 pkg4_types.UID
 		var v4 time.Time
@@ -57,7 +57,7 @@ func init() {
 	}
 }
 
-func (x *SecretSpec) CodecEncodeSelf(e *codec1978.Encoder) {
+func (x *PrivateKeySpec) CodecEncodeSelf(e *codec1978.Encoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperEncoder(e)
 	_, _, _ = h, z, r
@@ -71,14 +71,17 @@ func (x *SecretSpec) CodecEncodeSelf(e *codec1978.Encoder) {
 		} else {
 			yysep2 := !z.EncBinary()
 			yy2arr2 := z.EncBasicHandle().StructToArray
-			var yyq2 [5]bool
+			var yyq2 [3]bool
 			_, _, _ = yysep2, yyq2, yy2arr2
 			const yyr2 bool = false
+			yyq2[0] = x.Algorithm != ""
+			yyq2[1] = x.Size != 0
+			yyq2[2] = x.RotationPolicy != ""
 			var yynn2 int
 			if yyr2 || yy2arr2 {
-				r.EncodeArrayStart(5)
+				r.EncodeArrayStart(3)
 			} else {
-				yynn2 = 5
+				yynn2 = 0
 				for _, b := range yyq2 {
 					if b {
 						yynn2++
@@ -89,104 +92,76 @@ func (x *SecretSpec) CodecEncodeSelf(e *codec1978.Encoder) {
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				yysf4 := &x.Type
-				yysf4.CodecEncodeSelf(e)
-			} else {
-				z.EncSendContainerState(codecSelfer_containerMapKey6836)
-				r.EncodeString(codecSelferC_UTF86836, string("type"))
-				z.EncSendContainerState(codecSelfer_containerMapValue6836)
-				yysf5 := &x.Type
-				yysf5.CodecEncodeSelf(e)
-			}
-			if yyr2 || yy2arr2 {
-				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				yym7 := z.EncBinary()
-				_ = yym7
-				if false {
-				} else {
-					r.EncodeString(codecSelferC_UTF86836, string(x.Path))
-				}
-			} else {
-				z.EncSendContainerState(codecSelfer_containerMapKey6836)
-				r.EncodeString(codecSelferC_UTF86836, string("path"))
-				z.EncSendContainerState(codecSelfer_containerMapValue6836)
-				yym8 := z.EncBinary()
-				_ = yym8
-				if false {
-				} else {
-					r.EncodeString(codecSelferC_UTF86836, string(x.Path))
-				}
-			}
-			if yyr2 || yy2arr2 {
-				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if x.Data == nil {
-					r.EncodeNil()
-				} else {
-					yym10 := z.EncBinary()
-					_ = yym10
+				if yyq2[0] {
+					yym4 := z.EncBinary()
+					_ = yym4
 					if false {
 					} else {
-						z.F.EncMapStringIntfV(x.Data, false, e)
+						r.EncodeString(codecSelferC_UTF86836, string(x.Algorithm))
 					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
 				}
 			} else {
-				z.EncSendContainerState(codecSelfer_containerMapKey6836)
-				r.EncodeString(codecSelferC_UTF86836, string("data"))
-				z.EncSendContainerState(codecSelfer_containerMapValue6836)
-				if x.Data == nil {
-					r.EncodeNil()
-				} else {
-					yym11 := z.EncBinary()
-					_ = yym11
+				if yyq2[0] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("algorithm"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym5 := z.EncBinary()
+					_ = yym5
 					if false {
 					} else {
-						z.F.EncMapStringIntfV(x.Data, false, e)
+						r.EncodeString(codecSelferC_UTF86836, string(x.Algorithm))
 					}
 				}
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				yym13 := z.EncBinary()
-				_ = yym13
-				if false {
+				if yyq2[1] {
+					yym7 := z.EncBinary()
+					_ = yym7
+					if false {
+					} else {
+						r.EncodeInt(int64(x.Size))
+					}
 				} else {
-					r.EncodeInt(int64(x.Renew))
+					r.EncodeInt(0)
 				}
 			} else {
-				z.EncSendContainerState(codecSelfer_containerMapKey6836)
-				r.EncodeString(codecSelferC_UTF86836, string("renew"))
-				z.EncSendContainerState(codecSelfer_containerMapValue6836)
-				yym14 := z.EncBinary()
-				_ = yym14
-				if false {
-				} else {
-					r.EncodeInt(int64(x.Renew))
+				if yyq2[1] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("size"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym8 := z.EncBinary()
+					_ = yym8
+					if false {
+					} else {
+						r.EncodeInt(int64(x.Size))
+					}
 				}
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if x.Annotations == nil {
-					r.EncodeNil()
-				} else {
-					yym16 := z.EncBinary()
-					_ = yym16
+				if yyq2[2] {
+					yym10 := z.EncBinary()
+					_ = yym10
 					if false {
 					} else {
-						z.F.EncMapStringStringV(x.Annotations, false, e)
+						r.EncodeString(codecSelferC_UTF86836, string(x.RotationPolicy))
 					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
 				}
 			} else {
-				z.EncSendContainerState(codecSelfer_containerMapKey6836)
-				r.EncodeString(codecSelferC_UTF86836, string("annotations"))
-				z.EncSendContainerState(codecSelfer_containerMapValue6836)
-				if x.Annotations == nil {
-					r.EncodeNil()
-				} else {
-					yym17 := z.EncBinary()
-					_ = yym17
+				if yyq2[2] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("rotationPolicy"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym11 := z.EncBinary()
+					_ = yym11
 					if false {
 					} else {
-						z.F.EncMapStringStringV(x.Annotations, false, e)
+						r.EncodeString(codecSelferC_UTF86836, string(x.RotationPolicy))
 					}
 				}
 			}
@@ -199,7 +174,7 @@ func (x *SecretSpec) CodecEncodeSelf(e *codec1978.Encoder) {
 	}
 }
 
-func (x *SecretSpec) CodecDecodeSelf(d *codec1978.Decoder) {
+func (x *PrivateKeySpec) CodecDecodeSelf(d *codec1978.Decoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperDecoder(d)
 	_, _, _ = h, z, r
@@ -229,7 +204,7 @@ func (x *SecretSpec) CodecDecodeSelf(d *codec1978.Decoder) {
 	}
 }
 
-func (x *SecretSpec) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+func (x *PrivateKeySpec) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperDecoder(d)
 	_, _, _ = h, z, r
@@ -251,59 +226,40 @@ func (x *SecretSpec) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
 		yys3 := string(yys3Slc)
 		z.DecSendContainerState(codecSelfer_containerMapValue6836)
 		switch yys3 {
-		case "type":
-			if r.TryDecodeAsNil() {
-				x.Type = ""
-			} else {
-				yyv4 := &x.Type
-				yyv4.CodecDecodeSelf(d)
-			}
-		case "path":
-			if r.TryDecodeAsNil() {
-				x.Path = ""
-			} else {
-				yyv5 := &x.Path
-				yym6 := z.DecBinary()
-				_ = yym6
-				if false {
-				} else {
-					*((*string)(yyv5)) = r.DecodeString()
-				}
-			}
-		case "data":
+		case "algorithm":
 			if r.TryDecodeAsNil() {
-				x.Data = nil
+				x.Algorithm = ""
 			} else {
-				yyv7 := &x.Data
-				yym8 := z.DecBinary()
-				_ = yym8
+				yyv4 := &x.Algorithm
+				yym5 := z.DecBinary()
+				_ = yym5
 				if false {
 				} else {
-					z.F.DecMapStringIntfX(yyv7, false, d)
+					*((*string)(yyv4)) = r.DecodeString()
 				}
 			}
-		case "renew":
+		case "size":
 			if r.TryDecodeAsNil() {
-				x.Renew = 0
+				x.Size = 0
 			} else {
-				yyv9 := &x.Renew
-				yym10 := z.DecBinary()
-				_ = yym10
+				yyv6 := &x.Size
+				yym7 := z.DecBinary()
+				_ = yym7
 				if false {
 				} else {
-					*((*int64)(yyv9)) = int64(r.DecodeInt(64))
+					*((*int)(yyv6)) = int(r.DecodeInt(codecSelferBitsize6836))
 				}
 			}
-		case "annotations":
+		case "rotationPolicy":
 			if r.TryDecodeAsNil() {
-				x.Annotations = nil
+				x.RotationPolicy = ""
 			} else {
-				yyv11 := &x.Annotations
-				yym12 := z.DecBinary()
-				_ = yym12
+				yyv8 := &x.RotationPolicy
+				yym9 := z.DecBinary()
+				_ = yym9
 				if false {
 				} else {
-					z.F.DecMapStringStringX(yyv11, false, d)
+					*((*string)(yyv8)) = r.DecodeString()
 				}
 			}
 		default:
@@ -313,135 +269,96 @@ func (x *SecretSpec) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
 	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
 }
 
-func (x *SecretSpec) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+func (x *PrivateKeySpec) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperDecoder(d)
 	_, _, _ = h, z, r
-	var yyj13 int
-	var yyb13 bool
-	var yyhl13 bool = l >= 0
-	yyj13++
-	if yyhl13 {
-		yyb13 = yyj13 > l
-	} else {
-		yyb13 = r.CheckBreak()
-	}
-	if yyb13 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.Type = ""
-	} else {
-		yyv14 := &x.Type
-		yyv14.CodecDecodeSelf(d)
-	}
-	yyj13++
-	if yyhl13 {
-		yyb13 = yyj13 > l
-	} else {
-		yyb13 = r.CheckBreak()
-	}
-	if yyb13 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.Path = ""
-	} else {
-		yyv15 := &x.Path
-		yym16 := z.DecBinary()
-		_ = yym16
-		if false {
-		} else {
-			*((*string)(yyv15)) = r.DecodeString()
-		}
-	}
-	yyj13++
-	if yyhl13 {
-		yyb13 = yyj13 > l
+	var yyj10 int
+	var yyb10 bool
+	var yyhl10 bool = l >= 0
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
 	} else {
-		yyb13 = r.CheckBreak()
+		yyb10 = r.CheckBreak()
 	}
-	if yyb13 {
+	if yyb10 {
 		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
 		return
 	}
 	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
 	if r.TryDecodeAsNil() {
-		x.Data = nil
+		x.Algorithm = ""
 	} else {
-		yyv17 := &x.Data
-		yym18 := z.DecBinary()
-		_ = yym18
+		yyv11 := &x.Algorithm
+		yym12 := z.DecBinary()
+		_ = yym12
 		if false {
 		} else {
-			z.F.DecMapStringIntfX(yyv17, false, d)
+			*((*string)(yyv11)) = r.DecodeString()
 		}
 	}
-	yyj13++
-	if yyhl13 {
-		yyb13 = yyj13 > l
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
 	} else {
-		yyb13 = r.CheckBreak()
+		yyb10 = r.CheckBreak()
 	}
-	if yyb13 {
+	if yyb10 {
 		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
 		return
 	}
 	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
 	if r.TryDecodeAsNil() {
-		x.Renew = 0
+		x.Size = 0
 	} else {
-		yyv19 := &x.Renew
-		yym20 := z.DecBinary()
-		_ = yym20
+		yyv13 := &x.Size
+		yym14 := z.DecBinary()
+		_ = yym14
 		if false {
 		} else {
-			*((*int64)(yyv19)) = int64(r.DecodeInt(64))
+			*((*int)(yyv13)) = int(r.DecodeInt(codecSelferBitsize6836))
 		}
 	}
-	yyj13++
-	if yyhl13 {
-		yyb13 = yyj13 > l
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
 	} else {
-		yyb13 = r.CheckBreak()
+		yyb10 = r.CheckBreak()
 	}
-	if yyb13 {
+	if yyb10 {
 		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
 		return
 	}
 	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
 	if r.TryDecodeAsNil() {
-		x.Annotations = nil
+		x.RotationPolicy = ""
 	} else {
-		yyv21 := &x.Annotations
-		yym22 := z.DecBinary()
-		_ = yym22
+		yyv15 := &x.RotationPolicy
+		yym16 := z.DecBinary()
+		_ = yym16
 		if false {
 		} else {
-			z.F.DecMapStringStringX(yyv21, false, d)
+			*((*string)(yyv15)) = r.DecodeString()
 		}
 	}
 	for {
-		yyj13++
-		if yyhl13 {
-			yyb13 = yyj13 > l
+		yyj10++
+		if yyhl10 {
+			yyb10 = yyj10 > l
 		} else {
-			yyb13 = r.CheckBreak()
+			yyb10 = r.CheckBreak()
 		}
-		if yyb13 {
+		if yyb10 {
 			break
 		}
 		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-		z.DecStructFieldNotFound(yyj13-1, "")
+		z.DecStructFieldNotFound(yyj10-1, "")
 	}
 	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
 }
 
-func (x *SecretClaim) CodecEncodeSelf(e *codec1978.Encoder) {
+func (x *SecretSpec) CodecEncodeSelf(e *codec1978.Encoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperEncoder(e)
 	_, _, _ = h, z, r
@@ -455,17 +372,25 @@ func (x *SecretClaim) CodecEncodeSelf(e *codec1978.Encoder) {
 		} else {
 			yysep2 := !z.EncBinary()
 			yy2arr2 := z.EncBasicHandle().StructToArray
-			var yyq2 [4]bool
+			var yyq2 [16]bool
 			_, _, _ = yysep2, yyq2, yy2arr2
 			const yyr2 bool = false
-			yyq2[0] = x.Kind != ""
-			yyq2[1] = x.APIVersion != ""
-			yyq2[2] = true
+			yyq2[5] = x.Version != 0
+			yyq2[6] = len(x.Template) != 0
+			yyq2[7] = x.Flatten != false
+			yyq2[8] = x.FullChain != false
+			yyq2[9] = x.PrivateKey != nil
+			yyq2[10] = x.RenewBeforePercent != 0
+			yyq2[11] = x.ServiceAccountName != ""
+			yyq2[12] = x.VaultRole != ""
+			yyq2[13] = x.AppRoleSecretName != ""
+			yyq2[14] = x.SkipRevoke != false
+			yyq2[15] = x.AdoptExisting != false
 			var yynn2 int
 			if yyr2 || yy2arr2 {
-				r.EncodeArrayStart(4)
+				r.EncodeArrayStart(16)
 			} else {
-				yynn2 = 1
+				yynn2 = 5
 				for _, b := range yyq2 {
 					if b {
 						yynn2++
@@ -476,425 +401,385 @@ func (x *SecretClaim) CodecEncodeSelf(e *codec1978.Encoder) {
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if yyq2[0] {
-					yym4 := z.EncBinary()
-					_ = yym4
+				yysf4 := &x.Type
+				yysf4.CodecEncodeSelf(e)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("type"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				yysf5 := &x.Type
+				yysf5.CodecEncodeSelf(e)
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				yym7 := z.EncBinary()
+				_ = yym7
+				if false {
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, string(x.Path))
+				}
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("path"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				yym8 := z.EncBinary()
+				_ = yym8
+				if false {
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, string(x.Path))
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if x.Data == nil {
+					r.EncodeNil()
+				} else {
+					yym10 := z.EncBinary()
+					_ = yym10
 					if false {
 					} else {
-						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+						z.F.EncMapStringIntfV(x.Data, false, e)
 					}
+				}
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("data"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				if x.Data == nil {
+					r.EncodeNil()
 				} else {
-					r.EncodeString(codecSelferC_UTF86836, "")
+					yym11 := z.EncBinary()
+					_ = yym11
+					if false {
+					} else {
+						z.F.EncMapStringIntfV(x.Data, false, e)
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				yym13 := z.EncBinary()
+				_ = yym13
+				if false {
+				} else {
+					r.EncodeInt(int64(x.Renew))
 				}
 			} else {
-				if yyq2[0] {
-					z.EncSendContainerState(codecSelfer_containerMapKey6836)
-					r.EncodeString(codecSelferC_UTF86836, string("kind"))
-					z.EncSendContainerState(codecSelfer_containerMapValue6836)
-					yym5 := z.EncBinary()
-					_ = yym5
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("renew"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				yym14 := z.EncBinary()
+				_ = yym14
+				if false {
+				} else {
+					r.EncodeInt(int64(x.Renew))
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if x.Annotations == nil {
+					r.EncodeNil()
+				} else {
+					yym16 := z.EncBinary()
+					_ = yym16
 					if false {
 					} else {
-						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+						z.F.EncMapStringStringV(x.Annotations, false, e)
+					}
+				}
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("annotations"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				if x.Annotations == nil {
+					r.EncodeNil()
+				} else {
+					yym17 := z.EncBinary()
+					_ = yym17
+					if false {
+					} else {
+						z.F.EncMapStringStringV(x.Annotations, false, e)
 					}
 				}
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if yyq2[1] {
-					yym7 := z.EncBinary()
-					_ = yym7
+				if yyq2[5] {
+					yym19 := z.EncBinary()
+					_ = yym19
 					if false {
 					} else {
-						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+						r.EncodeInt(int64(x.Version))
 					}
 				} else {
-					r.EncodeString(codecSelferC_UTF86836, "")
+					r.EncodeInt(0)
 				}
 			} else {
-				if yyq2[1] {
+				if yyq2[5] {
 					z.EncSendContainerState(codecSelfer_containerMapKey6836)
-					r.EncodeString(codecSelferC_UTF86836, string("apiVersion"))
+					r.EncodeString(codecSelferC_UTF86836, string("version"))
 					z.EncSendContainerState(codecSelfer_containerMapValue6836)
-					yym8 := z.EncBinary()
-					_ = yym8
+					yym20 := z.EncBinary()
+					_ = yym20
 					if false {
 					} else {
-						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+						r.EncodeInt(int64(x.Version))
 					}
 				}
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if yyq2[2] {
-					yy10 := &x.ObjectMeta
-					yy10.CodecEncodeSelf(e)
+				if yyq2[6] {
+					if x.Template == nil {
+						r.EncodeNil()
+					} else {
+						yym22 := z.EncBinary()
+						_ = yym22
+						if false {
+						} else {
+							z.F.EncMapStringStringV(x.Template, false, e)
+						}
+					}
 				} else {
 					r.EncodeNil()
 				}
 			} else {
-				if yyq2[2] {
+				if yyq2[6] {
 					z.EncSendContainerState(codecSelfer_containerMapKey6836)
-					r.EncodeString(codecSelferC_UTF86836, string("metadata"))
+					r.EncodeString(codecSelferC_UTF86836, string("template"))
 					z.EncSendContainerState(codecSelfer_containerMapValue6836)
-					yy12 := &x.ObjectMeta
-					yy12.CodecEncodeSelf(e)
+					if x.Template == nil {
+						r.EncodeNil()
+					} else {
+						yym23 := z.EncBinary()
+						_ = yym23
+						if false {
+						} else {
+							z.F.EncMapStringStringV(x.Template, false, e)
+						}
+					}
 				}
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				yy15 := &x.Spec
-				yy15.CodecEncodeSelf(e)
+				if yyq2[7] {
+					yym25 := z.EncBinary()
+					_ = yym25
+					if false {
+					} else {
+						r.EncodeBool(bool(x.Flatten))
+					}
+				} else {
+					r.EncodeBool(false)
+				}
 			} else {
-				z.EncSendContainerState(codecSelfer_containerMapKey6836)
-				r.EncodeString(codecSelferC_UTF86836, string("spec"))
-				z.EncSendContainerState(codecSelfer_containerMapValue6836)
-				yy17 := &x.Spec
-				yy17.CodecEncodeSelf(e)
+				if yyq2[7] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("flatten"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym26 := z.EncBinary()
+					_ = yym26
+					if false {
+					} else {
+						r.EncodeBool(bool(x.Flatten))
+					}
+				}
 			}
 			if yyr2 || yy2arr2 {
-				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
-			} else {
-				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
-			}
-		}
-	}
-}
-
-func (x *SecretClaim) CodecDecodeSelf(d *codec1978.Decoder) {
-	var h codecSelfer6836
-	z, r := codec1978.GenHelperDecoder(d)
-	_, _, _ = h, z, r
-	yym1 := z.DecBinary()
-	_ = yym1
-	if false {
-	} else if z.HasExtensions() && z.DecExt(x) {
-	} else {
-		yyct2 := r.ContainerType()
-		if yyct2 == codecSelferValueTypeMap6836 {
-			yyl2 := r.ReadMapStart()
-			if yyl2 == 0 {
-				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
-			} else {
-				x.codecDecodeSelfFromMap(yyl2, d)
-			}
-		} else if yyct2 == codecSelferValueTypeArray6836 {
-			yyl2 := r.ReadArrayStart()
-			if yyl2 == 0 {
-				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[8] {
+					yym28 := z.EncBinary()
+					_ = yym28
+					if false {
+					} else {
+						r.EncodeBool(bool(x.FullChain))
+					}
+				} else {
+					r.EncodeBool(false)
+				}
 			} else {
-				x.codecDecodeSelfFromArray(yyl2, d)
-			}
-		} else {
-			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
-		}
-	}
-}
-
-func (x *SecretClaim) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
-	var h codecSelfer6836
-	z, r := codec1978.GenHelperDecoder(d)
-	_, _, _ = h, z, r
-	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
-	_ = yys3Slc
-	var yyhl3 bool = l >= 0
-	for yyj3 := 0; ; yyj3++ {
-		if yyhl3 {
-			if yyj3 >= l {
-				break
-			}
-		} else {
-			if r.CheckBreak() {
-				break
+				if yyq2[8] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("fullChain"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym29 := z.EncBinary()
+					_ = yym29
+					if false {
+					} else {
+						r.EncodeBool(bool(x.FullChain))
+					}
+				}
 			}
-		}
-		z.DecSendContainerState(codecSelfer_containerMapKey6836)
-		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
-		yys3 := string(yys3Slc)
-		z.DecSendContainerState(codecSelfer_containerMapValue6836)
-		switch yys3 {
-		case "kind":
-			if r.TryDecodeAsNil() {
-				x.Kind = ""
-			} else {
-				yyv4 := &x.Kind
-				yym5 := z.DecBinary()
-				_ = yym5
-				if false {
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[9] {
+					if x.PrivateKey == nil {
+						r.EncodeNil()
+					} else {
+						x.PrivateKey.CodecEncodeSelf(e)
+					}
 				} else {
-					*((*string)(yyv4)) = r.DecodeString()
+					r.EncodeNil()
 				}
-			}
-		case "apiVersion":
-			if r.TryDecodeAsNil() {
-				x.APIVersion = ""
 			} else {
-				yyv6 := &x.APIVersion
-				yym7 := z.DecBinary()
-				_ = yym7
-				if false {
-				} else {
-					*((*string)(yyv6)) = r.DecodeString()
+				if yyq2[9] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("privateKey"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					if x.PrivateKey == nil {
+						r.EncodeNil()
+					} else {
+						x.PrivateKey.CodecEncodeSelf(e)
+					}
 				}
 			}
-		case "metadata":
-			if r.TryDecodeAsNil() {
-				x.ObjectMeta = pkg3_api.ObjectMeta{}
-			} else {
-				yyv8 := &x.ObjectMeta
-				yyv8.CodecDecodeSelf(d)
-			}
-		case "spec":
-			if r.TryDecodeAsNil() {
-				x.Spec = SecretSpec{}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[10] {
+					yym34 := z.EncBinary()
+					_ = yym34
+					if false {
+					} else {
+						r.EncodeInt(int64(x.RenewBeforePercent))
+					}
+				} else {
+					r.EncodeInt(0)
+				}
 			} else {
-				yyv9 := &x.Spec
-				yyv9.CodecDecodeSelf(d)
+				if yyq2[10] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("renewBeforePercent"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym35 := z.EncBinary()
+					_ = yym35
+					if false {
+					} else {
+						r.EncodeInt(int64(x.RenewBeforePercent))
+					}
+				}
 			}
-		default:
-			z.DecStructFieldNotFound(-1, yys3)
-		} // end switch yys3
-	} // end for yyj3
-	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
-}
-
-func (x *SecretClaim) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
-	var h codecSelfer6836
-	z, r := codec1978.GenHelperDecoder(d)
-	_, _, _ = h, z, r
-	var yyj10 int
-	var yyb10 bool
-	var yyhl10 bool = l >= 0
-	yyj10++
-	if yyhl10 {
-		yyb10 = yyj10 > l
-	} else {
-		yyb10 = r.CheckBreak()
-	}
-	if yyb10 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.Kind = ""
-	} else {
-		yyv11 := &x.Kind
-		yym12 := z.DecBinary()
-		_ = yym12
-		if false {
-		} else {
-			*((*string)(yyv11)) = r.DecodeString()
-		}
-	}
-	yyj10++
-	if yyhl10 {
-		yyb10 = yyj10 > l
-	} else {
-		yyb10 = r.CheckBreak()
-	}
-	if yyb10 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.APIVersion = ""
-	} else {
-		yyv13 := &x.APIVersion
-		yym14 := z.DecBinary()
-		_ = yym14
-		if false {
-		} else {
-			*((*string)(yyv13)) = r.DecodeString()
-		}
-	}
-	yyj10++
-	if yyhl10 {
-		yyb10 = yyj10 > l
-	} else {
-		yyb10 = r.CheckBreak()
-	}
-	if yyb10 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.ObjectMeta = pkg3_api.ObjectMeta{}
-	} else {
-		yyv15 := &x.ObjectMeta
-		yyv15.CodecDecodeSelf(d)
-	}
-	yyj10++
-	if yyhl10 {
-		yyb10 = yyj10 > l
-	} else {
-		yyb10 = r.CheckBreak()
-	}
-	if yyb10 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.Spec = SecretSpec{}
-	} else {
-		yyv16 := &x.Spec
-		yyv16.CodecDecodeSelf(d)
-	}
-	for {
-		yyj10++
-		if yyhl10 {
-			yyb10 = yyj10 > l
-		} else {
-			yyb10 = r.CheckBreak()
-		}
-		if yyb10 {
-			break
-		}
-		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-		z.DecStructFieldNotFound(yyj10-1, "")
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-}
-
-func (x *SecretClaimList) CodecEncodeSelf(e *codec1978.Encoder) {
-	var h codecSelfer6836
-	z, r := codec1978.GenHelperEncoder(e)
-	_, _, _ = h, z, r
-	if x == nil {
-		r.EncodeNil()
-	} else {
-		yym1 := z.EncBinary()
-		_ = yym1
-		if false {
-		} else if z.HasExtensions() && z.EncExt(x) {
-		} else {
-			yysep2 := !z.EncBinary()
-			yy2arr2 := z.EncBasicHandle().StructToArray
-			var yyq2 [4]bool
-			_, _, _ = yysep2, yyq2, yy2arr2
-			const yyr2 bool = false
-			yyq2[0] = x.Kind != ""
-			yyq2[1] = x.APIVersion != ""
-			yyq2[2] = true
-			var yynn2 int
 			if yyr2 || yy2arr2 {
-				r.EncodeArrayStart(4)
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[11] {
+					yym37 := z.EncBinary()
+					_ = yym37
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.ServiceAccountName))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
 			} else {
-				yynn2 = 1
-				for _, b := range yyq2 {
-					if b {
-						yynn2++
+				if yyq2[11] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("serviceAccountName"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym38 := z.EncBinary()
+					_ = yym38
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.ServiceAccountName))
 					}
 				}
-				r.EncodeMapStart(yynn2)
-				yynn2 = 0
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if yyq2[0] {
-					yym4 := z.EncBinary()
-					_ = yym4
+				if yyq2[12] {
+					yym40 := z.EncBinary()
+					_ = yym40
 					if false {
 					} else {
-						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+						r.EncodeString(codecSelferC_UTF86836, string(x.VaultRole))
 					}
 				} else {
 					r.EncodeString(codecSelferC_UTF86836, "")
 				}
 			} else {
-				if yyq2[0] {
+				if yyq2[12] {
 					z.EncSendContainerState(codecSelfer_containerMapKey6836)
-					r.EncodeString(codecSelferC_UTF86836, string("kind"))
+					r.EncodeString(codecSelferC_UTF86836, string("vaultRole"))
 					z.EncSendContainerState(codecSelfer_containerMapValue6836)
-					yym5 := z.EncBinary()
-					_ = yym5
+					yym41 := z.EncBinary()
+					_ = yym41
 					if false {
 					} else {
-						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+						r.EncodeString(codecSelferC_UTF86836, string(x.VaultRole))
 					}
 				}
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if yyq2[1] {
-					yym7 := z.EncBinary()
-					_ = yym7
+				if yyq2[13] {
+					yym43 := z.EncBinary()
+					_ = yym43
 					if false {
 					} else {
-						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+						r.EncodeString(codecSelferC_UTF86836, string(x.AppRoleSecretName))
 					}
 				} else {
 					r.EncodeString(codecSelferC_UTF86836, "")
 				}
 			} else {
-				if yyq2[1] {
+				if yyq2[13] {
 					z.EncSendContainerState(codecSelfer_containerMapKey6836)
-					r.EncodeString(codecSelferC_UTF86836, string("apiVersion"))
+					r.EncodeString(codecSelferC_UTF86836, string("appRoleSecretName"))
 					z.EncSendContainerState(codecSelfer_containerMapValue6836)
-					yym8 := z.EncBinary()
-					_ = yym8
+					yym44 := z.EncBinary()
+					_ = yym44
 					if false {
 					} else {
-						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+						r.EncodeString(codecSelferC_UTF86836, string(x.AppRoleSecretName))
 					}
 				}
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if yyq2[2] {
-					yy10 := &x.ListMeta
-					yym11 := z.EncBinary()
-					_ = yym11
+				if yyq2[14] {
+					yym46 := z.EncBinary()
+					_ = yym46
 					if false {
-					} else if z.HasExtensions() && z.EncExt(yy10) {
 					} else {
-						z.EncFallback(yy10)
+						r.EncodeBool(bool(x.SkipRevoke))
 					}
 				} else {
-					r.EncodeNil()
+					r.EncodeBool(false)
 				}
 			} else {
-				if yyq2[2] {
+				if yyq2[14] {
 					z.EncSendContainerState(codecSelfer_containerMapKey6836)
-					r.EncodeString(codecSelferC_UTF86836, string("metadata"))
+					r.EncodeString(codecSelferC_UTF86836, string("skipRevoke"))
 					z.EncSendContainerState(codecSelfer_containerMapValue6836)
-					yy12 := &x.ListMeta
-					yym13 := z.EncBinary()
-					_ = yym13
+					yym47 := z.EncBinary()
+					_ = yym47
 					if false {
-					} else if z.HasExtensions() && z.EncExt(yy12) {
 					} else {
-						z.EncFallback(yy12)
+						r.EncodeBool(bool(x.SkipRevoke))
 					}
 				}
 			}
 			if yyr2 || yy2arr2 {
 				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
-				if x.Items == nil {
-					r.EncodeNil()
-				} else {
-					yym15 := z.EncBinary()
-					_ = yym15
+				if yyq2[15] {
+					yym49 := z.EncBinary()
+					_ = yym49
 					if false {
 					} else {
-						h.encSliceSecretClaim(([]SecretClaim)(x.Items), e)
+						r.EncodeBool(bool(x.AdoptExisting))
 					}
+				} else {
+					r.EncodeBool(false)
 				}
 			} else {
-				z.EncSendContainerState(codecSelfer_containerMapKey6836)
-				r.EncodeString(codecSelferC_UTF86836, string("items"))
-				z.EncSendContainerState(codecSelfer_containerMapValue6836)
-				if x.Items == nil {
-					r.EncodeNil()
-				} else {
-					yym16 := z.EncBinary()
-					_ = yym16
+				if yyq2[15] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("adoptExisting"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym50 := z.EncBinary()
+					_ = yym50
 					if false {
 					} else {
-						h.encSliceSecretClaim(([]SecretClaim)(x.Items), e)
+						r.EncodeBool(bool(x.AdoptExisting))
 					}
 				}
 			}
@@ -907,7 +792,7 @@ func (x *SecretClaimList) CodecEncodeSelf(e *codec1978.Encoder) {
 	}
 }
 
-func (x *SecretClaimList) CodecDecodeSelf(d *codec1978.Decoder) {
+func (x *SecretSpec) CodecDecodeSelf(d *codec1978.Decoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperDecoder(d)
 	_, _, _ = h, z, r
@@ -937,197 +822,3812 @@ func (x *SecretClaimList) CodecDecodeSelf(d *codec1978.Decoder) {
 	}
 }
 
-func (x *SecretClaimList) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+func (x *SecretSpec) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "type":
+			if r.TryDecodeAsNil() {
+				x.Type = ""
+			} else {
+				yyv4 := &x.Type
+				yyv4.CodecDecodeSelf(d)
+			}
+		case "path":
+			if r.TryDecodeAsNil() {
+				x.Path = ""
+			} else {
+				yyv5 := &x.Path
+				yym6 := z.DecBinary()
+				_ = yym6
+				if false {
+				} else {
+					*((*string)(yyv5)) = r.DecodeString()
+				}
+			}
+		case "data":
+			if r.TryDecodeAsNil() {
+				x.Data = nil
+			} else {
+				yyv7 := &x.Data
+				yym8 := z.DecBinary()
+				_ = yym8
+				if false {
+				} else {
+					z.F.DecMapStringIntfX(yyv7, false, d)
+				}
+			}
+		case "renew":
+			if r.TryDecodeAsNil() {
+				x.Renew = 0
+			} else {
+				yyv9 := &x.Renew
+				yym10 := z.DecBinary()
+				_ = yym10
+				if false {
+				} else {
+					*((*int64)(yyv9)) = int64(r.DecodeInt(64))
+				}
+			}
+		case "annotations":
+			if r.TryDecodeAsNil() {
+				x.Annotations = nil
+			} else {
+				yyv11 := &x.Annotations
+				yym12 := z.DecBinary()
+				_ = yym12
+				if false {
+				} else {
+					z.F.DecMapStringStringX(yyv11, false, d)
+				}
+			}
+		case "version":
+			if r.TryDecodeAsNil() {
+				x.Version = 0
+			} else {
+				yyv13 := &x.Version
+				yym14 := z.DecBinary()
+				_ = yym14
+				if false {
+				} else {
+					*((*int)(yyv13)) = int(r.DecodeInt(codecSelferBitsize6836))
+				}
+			}
+		case "template":
+			if r.TryDecodeAsNil() {
+				x.Template = nil
+			} else {
+				yyv15 := &x.Template
+				yym16 := z.DecBinary()
+				_ = yym16
+				if false {
+				} else {
+					z.F.DecMapStringStringX(yyv15, false, d)
+				}
+			}
+		case "flatten":
+			if r.TryDecodeAsNil() {
+				x.Flatten = false
+			} else {
+				yyv17 := &x.Flatten
+				yym18 := z.DecBinary()
+				_ = yym18
+				if false {
+				} else {
+					*((*bool)(yyv17)) = r.DecodeBool()
+				}
+			}
+		case "fullChain":
+			if r.TryDecodeAsNil() {
+				x.FullChain = false
+			} else {
+				yyv19 := &x.FullChain
+				yym20 := z.DecBinary()
+				_ = yym20
+				if false {
+				} else {
+					*((*bool)(yyv19)) = r.DecodeBool()
+				}
+			}
+		case "privateKey":
+			if r.TryDecodeAsNil() {
+				if x.PrivateKey != nil {
+					x.PrivateKey = nil
+				}
+			} else {
+				if x.PrivateKey == nil {
+					x.PrivateKey = new(PrivateKeySpec)
+				}
+				x.PrivateKey.CodecDecodeSelf(d)
+			}
+		case "renewBeforePercent":
+			if r.TryDecodeAsNil() {
+				x.RenewBeforePercent = 0
+			} else {
+				yyv22 := &x.RenewBeforePercent
+				yym23 := z.DecBinary()
+				_ = yym23
+				if false {
+				} else {
+					*((*int)(yyv22)) = int(r.DecodeInt(codecSelferBitsize6836))
+				}
+			}
+		case "serviceAccountName":
+			if r.TryDecodeAsNil() {
+				x.ServiceAccountName = ""
+			} else {
+				yyv24 := &x.ServiceAccountName
+				yym25 := z.DecBinary()
+				_ = yym25
+				if false {
+				} else {
+					*((*string)(yyv24)) = r.DecodeString()
+				}
+			}
+		case "vaultRole":
+			if r.TryDecodeAsNil() {
+				x.VaultRole = ""
+			} else {
+				yyv26 := &x.VaultRole
+				yym27 := z.DecBinary()
+				_ = yym27
+				if false {
+				} else {
+					*((*string)(yyv26)) = r.DecodeString()
+				}
+			}
+		case "appRoleSecretName":
+			if r.TryDecodeAsNil() {
+				x.AppRoleSecretName = ""
+			} else {
+				yyv28 := &x.AppRoleSecretName
+				yym29 := z.DecBinary()
+				_ = yym29
+				if false {
+				} else {
+					*((*string)(yyv28)) = r.DecodeString()
+				}
+			}
+		case "skipRevoke":
+			if r.TryDecodeAsNil() {
+				x.SkipRevoke = false
+			} else {
+				yyv30 := &x.SkipRevoke
+				yym31 := z.DecBinary()
+				_ = yym31
+				if false {
+				} else {
+					*((*bool)(yyv30)) = r.DecodeBool()
+				}
+			}
+		case "adoptExisting":
+			if r.TryDecodeAsNil() {
+				x.AdoptExisting = false
+			} else {
+				yyv32 := &x.AdoptExisting
+				yym33 := z.DecBinary()
+				_ = yym33
+				if false {
+				} else {
+					*((*bool)(yyv32)) = r.DecodeBool()
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *SecretSpec) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj34 int
+	var yyb34 bool
+	var yyhl34 bool = l >= 0
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Type = ""
+	} else {
+		yyv35 := &x.Type
+		yyv35.CodecDecodeSelf(d)
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Path = ""
+	} else {
+		yyv36 := &x.Path
+		yym37 := z.DecBinary()
+		_ = yym37
+		if false {
+		} else {
+			*((*string)(yyv36)) = r.DecodeString()
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Data = nil
+	} else {
+		yyv38 := &x.Data
+		yym39 := z.DecBinary()
+		_ = yym39
+		if false {
+		} else {
+			z.F.DecMapStringIntfX(yyv38, false, d)
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Renew = 0
+	} else {
+		yyv40 := &x.Renew
+		yym41 := z.DecBinary()
+		_ = yym41
+		if false {
+		} else {
+			*((*int64)(yyv40)) = int64(r.DecodeInt(64))
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Annotations = nil
+	} else {
+		yyv42 := &x.Annotations
+		yym43 := z.DecBinary()
+		_ = yym43
+		if false {
+		} else {
+			z.F.DecMapStringStringX(yyv42, false, d)
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Version = 0
+	} else {
+		yyv44 := &x.Version
+		yym45 := z.DecBinary()
+		_ = yym45
+		if false {
+		} else {
+			*((*int)(yyv44)) = int(r.DecodeInt(codecSelferBitsize6836))
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Template = nil
+	} else {
+		yyv46 := &x.Template
+		yym47 := z.DecBinary()
+		_ = yym47
+		if false {
+		} else {
+			z.F.DecMapStringStringX(yyv46, false, d)
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Flatten = false
+	} else {
+		yyv48 := &x.Flatten
+		yym49 := z.DecBinary()
+		_ = yym49
+		if false {
+		} else {
+			*((*bool)(yyv48)) = r.DecodeBool()
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.FullChain = false
+	} else {
+		yyv50 := &x.FullChain
+		yym51 := z.DecBinary()
+		_ = yym51
+		if false {
+		} else {
+			*((*bool)(yyv50)) = r.DecodeBool()
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		if x.PrivateKey != nil {
+			x.PrivateKey = nil
+		}
+	} else {
+		if x.PrivateKey == nil {
+			x.PrivateKey = new(PrivateKeySpec)
+		}
+		x.PrivateKey.CodecDecodeSelf(d)
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.RenewBeforePercent = 0
+	} else {
+		yyv53 := &x.RenewBeforePercent
+		yym54 := z.DecBinary()
+		_ = yym54
+		if false {
+		} else {
+			*((*int)(yyv53)) = int(r.DecodeInt(codecSelferBitsize6836))
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.ServiceAccountName = ""
+	} else {
+		yyv55 := &x.ServiceAccountName
+		yym56 := z.DecBinary()
+		_ = yym56
+		if false {
+		} else {
+			*((*string)(yyv55)) = r.DecodeString()
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.VaultRole = ""
+	} else {
+		yyv57 := &x.VaultRole
+		yym58 := z.DecBinary()
+		_ = yym58
+		if false {
+		} else {
+			*((*string)(yyv57)) = r.DecodeString()
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.AppRoleSecretName = ""
+	} else {
+		yyv59 := &x.AppRoleSecretName
+		yym60 := z.DecBinary()
+		_ = yym60
+		if false {
+		} else {
+			*((*string)(yyv59)) = r.DecodeString()
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.SkipRevoke = false
+	} else {
+		yyv61 := &x.SkipRevoke
+		yym62 := z.DecBinary()
+		_ = yym62
+		if false {
+		} else {
+			*((*bool)(yyv61)) = r.DecodeBool()
+		}
+	}
+	yyj34++
+	if yyhl34 {
+		yyb34 = yyj34 > l
+	} else {
+		yyb34 = r.CheckBreak()
+	}
+	if yyb34 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.AdoptExisting = false
+	} else {
+		yyv63 := &x.AdoptExisting
+		yym64 := z.DecBinary()
+		_ = yym64
+		if false {
+		} else {
+			*((*bool)(yyv63)) = r.DecodeBool()
+		}
+	}
+	for {
+		yyj34++
+		if yyhl34 {
+			yyb34 = yyj34 > l
+		} else {
+			yyb34 = r.CheckBreak()
+		}
+		if yyb34 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj34-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x *SecretClaimCondition) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yym1 := z.EncBinary()
+		_ = yym1
+		if false {
+		} else if z.HasExtensions() && z.EncExt(x) {
+		} else {
+			yysep2 := !z.EncBinary()
+			yy2arr2 := z.EncBasicHandle().StructToArray
+			var yyq2 [5]bool
+			_, _, _ = yysep2, yyq2, yy2arr2
+			const yyr2 bool = false
+			yyq2[2] = true
+			yyq2[3] = x.Reason != ""
+			yyq2[4] = x.Message != ""
+			var yynn2 int
+			if yyr2 || yy2arr2 {
+				r.EncodeArrayStart(5)
+			} else {
+				yynn2 = 2
+				for _, b := range yyq2 {
+					if b {
+						yynn2++
+					}
+				}
+				r.EncodeMapStart(yynn2)
+				yynn2 = 0
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				yym4 := z.EncBinary()
+				_ = yym4
+				if false {
+				} else if z.HasExtensions() && z.EncExt(x.Type) {
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, string(x.Type))
+				}
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("type"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				yym5 := z.EncBinary()
+				_ = yym5
+				if false {
+				} else if z.HasExtensions() && z.EncExt(x.Type) {
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, string(x.Type))
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				yysf7 := &x.Status
+				yysf7.CodecEncodeSelf(e)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("status"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				yysf8 := &x.Status
+				yysf8.CodecEncodeSelf(e)
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[2] {
+					yy10 := &x.LastTransitionTime
+					yym11 := z.EncBinary()
+					_ = yym11
+					if false {
+					} else if z.HasExtensions() && z.EncExt(yy10) {
+					} else if yym11 {
+						z.EncBinaryMarshal(yy10)
+					} else if !yym11 && z.IsJSONHandle() {
+						z.EncJSONMarshal(yy10)
+					} else {
+						z.EncFallback(yy10)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[2] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("lastTransitionTime"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yy12 := &x.LastTransitionTime
+					yym13 := z.EncBinary()
+					_ = yym13
+					if false {
+					} else if z.HasExtensions() && z.EncExt(yy12) {
+					} else if yym13 {
+						z.EncBinaryMarshal(yy12)
+					} else if !yym13 && z.IsJSONHandle() {
+						z.EncJSONMarshal(yy12)
+					} else {
+						z.EncFallback(yy12)
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[3] {
+					yym15 := z.EncBinary()
+					_ = yym15
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Reason))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[3] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("reason"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym16 := z.EncBinary()
+					_ = yym16
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Reason))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[4] {
+					yym18 := z.EncBinary()
+					_ = yym18
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Message))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[4] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("message"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym19 := z.EncBinary()
+					_ = yym19
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Message))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
+			}
+		}
+	}
+}
+
+func (x *SecretClaimCondition) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	yym1 := z.DecBinary()
+	_ = yym1
+	if false {
+	} else if z.HasExtensions() && z.DecExt(x) {
+	} else {
+		yyct2 := r.ContainerType()
+		if yyct2 == codecSelferValueTypeMap6836 {
+			yyl2 := r.ReadMapStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+			} else {
+				x.codecDecodeSelfFromMap(yyl2, d)
+			}
+		} else if yyct2 == codecSelferValueTypeArray6836 {
+			yyl2 := r.ReadArrayStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				x.codecDecodeSelfFromArray(yyl2, d)
+			}
+		} else {
+			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
+		}
+	}
+}
+
+func (x *SecretClaimCondition) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "type":
+			if r.TryDecodeAsNil() {
+				x.Type = ""
+			} else {
+				yyv4 := &x.Type
+				yym5 := z.DecBinary()
+				_ = yym5
+				if false {
+				} else if z.HasExtensions() && z.DecExt(yyv4) {
+				} else {
+					*((*string)(yyv4)) = r.DecodeString()
+				}
+			}
+		case "status":
+			if r.TryDecodeAsNil() {
+				x.Status = ""
+			} else {
+				yyv6 := &x.Status
+				yyv6.CodecDecodeSelf(d)
+			}
+		case "lastTransitionTime":
+			if r.TryDecodeAsNil() {
+				x.LastTransitionTime = pkg2_unversioned.Time{}
+			} else {
+				yyv7 := &x.LastTransitionTime
+				yym8 := z.DecBinary()
+				_ = yym8
+				if false {
+				} else if z.HasExtensions() && z.DecExt(yyv7) {
+				} else if yym8 {
+					z.DecBinaryUnmarshal(yyv7)
+				} else if !yym8 && z.IsJSONHandle() {
+					z.DecJSONUnmarshal(yyv7)
+				} else {
+					z.DecFallback(yyv7, false)
+				}
+			}
+		case "reason":
+			if r.TryDecodeAsNil() {
+				x.Reason = ""
+			} else {
+				yyv9 := &x.Reason
+				yym10 := z.DecBinary()
+				_ = yym10
+				if false {
+				} else {
+					*((*string)(yyv9)) = r.DecodeString()
+				}
+			}
+		case "message":
+			if r.TryDecodeAsNil() {
+				x.Message = ""
+			} else {
+				yyv11 := &x.Message
+				yym12 := z.DecBinary()
+				_ = yym12
+				if false {
+				} else {
+					*((*string)(yyv11)) = r.DecodeString()
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *SecretClaimCondition) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj13 int
+	var yyb13 bool
+	var yyhl13 bool = l >= 0
+	yyj13++
+	if yyhl13 {
+		yyb13 = yyj13 > l
+	} else {
+		yyb13 = r.CheckBreak()
+	}
+	if yyb13 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Type = ""
+	} else {
+		yyv14 := &x.Type
+		yym15 := z.DecBinary()
+		_ = yym15
+		if false {
+		} else if z.HasExtensions() && z.DecExt(yyv14) {
+		} else {
+			*((*string)(yyv14)) = r.DecodeString()
+		}
+	}
+	yyj13++
+	if yyhl13 {
+		yyb13 = yyj13 > l
+	} else {
+		yyb13 = r.CheckBreak()
+	}
+	if yyb13 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Status = ""
+	} else {
+		yyv16 := &x.Status
+		yyv16.CodecDecodeSelf(d)
+	}
+	yyj13++
+	if yyhl13 {
+		yyb13 = yyj13 > l
+	} else {
+		yyb13 = r.CheckBreak()
+	}
+	if yyb13 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.LastTransitionTime = pkg2_unversioned.Time{}
+	} else {
+		yyv17 := &x.LastTransitionTime
+		yym18 := z.DecBinary()
+		_ = yym18
+		if false {
+		} else if z.HasExtensions() && z.DecExt(yyv17) {
+		} else if yym18 {
+			z.DecBinaryUnmarshal(yyv17)
+		} else if !yym18 && z.IsJSONHandle() {
+			z.DecJSONUnmarshal(yyv17)
+		} else {
+			z.DecFallback(yyv17, false)
+		}
+	}
+	yyj13++
+	if yyhl13 {
+		yyb13 = yyj13 > l
+	} else {
+		yyb13 = r.CheckBreak()
+	}
+	if yyb13 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Reason = ""
+	} else {
+		yyv19 := &x.Reason
+		yym20 := z.DecBinary()
+		_ = yym20
+		if false {
+		} else {
+			*((*string)(yyv19)) = r.DecodeString()
+		}
+	}
+	yyj13++
+	if yyhl13 {
+		yyb13 = yyj13 > l
+	} else {
+		yyb13 = r.CheckBreak()
+	}
+	if yyb13 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Message = ""
+	} else {
+		yyv21 := &x.Message
+		yym22 := z.DecBinary()
+		_ = yym22
+		if false {
+		} else {
+			*((*string)(yyv21)) = r.DecodeString()
+		}
+	}
+	for {
+		yyj13++
+		if yyhl13 {
+			yyb13 = yyj13 > l
+		} else {
+			yyb13 = r.CheckBreak()
+		}
+		if yyb13 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj13-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x *SecretClaimStatus) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yym1 := z.EncBinary()
+		_ = yym1
+		if false {
+		} else if z.HasExtensions() && z.EncExt(x) {
+		} else {
+			yysep2 := !z.EncBinary()
+			yy2arr2 := z.EncBasicHandle().StructToArray
+			var yyq2 [6]bool
+			_, _, _ = yysep2, yyq2, yy2arr2
+			const yyr2 bool = false
+			yyq2[0] = x.ObservedGeneration != 0
+			yyq2[1] = len(x.Conditions) != 0
+			yyq2[2] = x.LeaseExpiration != nil
+			yyq2[3] = x.LastRenewTime != nil
+			yyq2[4] = x.LastRotationTime != nil
+			yyq2[5] = x.LastError != ""
+			var yynn2 int
+			if yyr2 || yy2arr2 {
+				r.EncodeArrayStart(6)
+			} else {
+				yynn2 = 0
+				for _, b := range yyq2 {
+					if b {
+						yynn2++
+					}
+				}
+				r.EncodeMapStart(yynn2)
+				yynn2 = 0
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[0] {
+					yym4 := z.EncBinary()
+					_ = yym4
+					if false {
+					} else {
+						r.EncodeInt(int64(x.ObservedGeneration))
+					}
+				} else {
+					r.EncodeInt(0)
+				}
+			} else {
+				if yyq2[0] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("observedGeneration"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym5 := z.EncBinary()
+					_ = yym5
+					if false {
+					} else {
+						r.EncodeInt(int64(x.ObservedGeneration))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[1] {
+					if x.Conditions == nil {
+						r.EncodeNil()
+					} else {
+						yym7 := z.EncBinary()
+						_ = yym7
+						if false {
+						} else {
+							h.encSliceSecretClaimCondition(([]SecretClaimCondition)(x.Conditions), e)
+						}
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[1] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("conditions"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					if x.Conditions == nil {
+						r.EncodeNil()
+					} else {
+						yym8 := z.EncBinary()
+						_ = yym8
+						if false {
+						} else {
+							h.encSliceSecretClaimCondition(([]SecretClaimCondition)(x.Conditions), e)
+						}
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[2] {
+					if x.LeaseExpiration == nil {
+						r.EncodeNil()
+					} else {
+						yym10 := z.EncBinary()
+						_ = yym10
+						if false {
+						} else if z.HasExtensions() && z.EncExt(x.LeaseExpiration) {
+						} else if yym10 {
+							z.EncBinaryMarshal(x.LeaseExpiration)
+						} else if !yym10 && z.IsJSONHandle() {
+							z.EncJSONMarshal(x.LeaseExpiration)
+						} else {
+							z.EncFallback(x.LeaseExpiration)
+						}
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[2] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("leaseExpiration"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					if x.LeaseExpiration == nil {
+						r.EncodeNil()
+					} else {
+						yym11 := z.EncBinary()
+						_ = yym11
+						if false {
+						} else if z.HasExtensions() && z.EncExt(x.LeaseExpiration) {
+						} else if yym11 {
+							z.EncBinaryMarshal(x.LeaseExpiration)
+						} else if !yym11 && z.IsJSONHandle() {
+							z.EncJSONMarshal(x.LeaseExpiration)
+						} else {
+							z.EncFallback(x.LeaseExpiration)
+						}
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[3] {
+					if x.LastRenewTime == nil {
+						r.EncodeNil()
+					} else {
+						yym13 := z.EncBinary()
+						_ = yym13
+						if false {
+						} else if z.HasExtensions() && z.EncExt(x.LastRenewTime) {
+						} else if yym13 {
+							z.EncBinaryMarshal(x.LastRenewTime)
+						} else if !yym13 && z.IsJSONHandle() {
+							z.EncJSONMarshal(x.LastRenewTime)
+						} else {
+							z.EncFallback(x.LastRenewTime)
+						}
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[3] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("lastRenewTime"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					if x.LastRenewTime == nil {
+						r.EncodeNil()
+					} else {
+						yym14 := z.EncBinary()
+						_ = yym14
+						if false {
+						} else if z.HasExtensions() && z.EncExt(x.LastRenewTime) {
+						} else if yym14 {
+							z.EncBinaryMarshal(x.LastRenewTime)
+						} else if !yym14 && z.IsJSONHandle() {
+							z.EncJSONMarshal(x.LastRenewTime)
+						} else {
+							z.EncFallback(x.LastRenewTime)
+						}
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[4] {
+					if x.LastRotationTime == nil {
+						r.EncodeNil()
+					} else {
+						yym16 := z.EncBinary()
+						_ = yym16
+						if false {
+						} else if z.HasExtensions() && z.EncExt(x.LastRotationTime) {
+						} else if yym16 {
+							z.EncBinaryMarshal(x.LastRotationTime)
+						} else if !yym16 && z.IsJSONHandle() {
+							z.EncJSONMarshal(x.LastRotationTime)
+						} else {
+							z.EncFallback(x.LastRotationTime)
+						}
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[4] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("lastRotationTime"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					if x.LastRotationTime == nil {
+						r.EncodeNil()
+					} else {
+						yym17 := z.EncBinary()
+						_ = yym17
+						if false {
+						} else if z.HasExtensions() && z.EncExt(x.LastRotationTime) {
+						} else if yym17 {
+							z.EncBinaryMarshal(x.LastRotationTime)
+						} else if !yym17 && z.IsJSONHandle() {
+							z.EncJSONMarshal(x.LastRotationTime)
+						} else {
+							z.EncFallback(x.LastRotationTime)
+						}
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[5] {
+					yym19 := z.EncBinary()
+					_ = yym19
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.LastError))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[5] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("lastError"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym20 := z.EncBinary()
+					_ = yym20
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.LastError))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
+			}
+		}
+	}
+}
+
+func (x *SecretClaimStatus) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	yym1 := z.DecBinary()
+	_ = yym1
+	if false {
+	} else if z.HasExtensions() && z.DecExt(x) {
+	} else {
+		yyct2 := r.ContainerType()
+		if yyct2 == codecSelferValueTypeMap6836 {
+			yyl2 := r.ReadMapStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+			} else {
+				x.codecDecodeSelfFromMap(yyl2, d)
+			}
+		} else if yyct2 == codecSelferValueTypeArray6836 {
+			yyl2 := r.ReadArrayStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				x.codecDecodeSelfFromArray(yyl2, d)
+			}
+		} else {
+			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
+		}
+	}
+}
+
+func (x *SecretClaimStatus) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "observedGeneration":
+			if r.TryDecodeAsNil() {
+				x.ObservedGeneration = 0
+			} else {
+				yyv4 := &x.ObservedGeneration
+				yym5 := z.DecBinary()
+				_ = yym5
+				if false {
+				} else {
+					*((*int64)(yyv4)) = int64(r.DecodeInt(64))
+				}
+			}
+		case "conditions":
+			if r.TryDecodeAsNil() {
+				x.Conditions = nil
+			} else {
+				yyv6 := &x.Conditions
+				yym7 := z.DecBinary()
+				_ = yym7
+				if false {
+				} else {
+					h.decSliceSecretClaimCondition((*[]SecretClaimCondition)(yyv6), d)
+				}
+			}
+		case "leaseExpiration":
+			if r.TryDecodeAsNil() {
+				if x.LeaseExpiration != nil {
+					x.LeaseExpiration = nil
+				}
+			} else {
+				if x.LeaseExpiration == nil {
+					x.LeaseExpiration = new(pkg2_unversioned.Time)
+				}
+				yym9 := z.DecBinary()
+				_ = yym9
+				if false {
+				} else if z.HasExtensions() && z.DecExt(x.LeaseExpiration) {
+				} else if yym9 {
+					z.DecBinaryUnmarshal(x.LeaseExpiration)
+				} else if !yym9 && z.IsJSONHandle() {
+					z.DecJSONUnmarshal(x.LeaseExpiration)
+				} else {
+					z.DecFallback(x.LeaseExpiration, false)
+				}
+			}
+		case "lastRenewTime":
+			if r.TryDecodeAsNil() {
+				if x.LastRenewTime != nil {
+					x.LastRenewTime = nil
+				}
+			} else {
+				if x.LastRenewTime == nil {
+					x.LastRenewTime = new(pkg2_unversioned.Time)
+				}
+				yym11 := z.DecBinary()
+				_ = yym11
+				if false {
+				} else if z.HasExtensions() && z.DecExt(x.LastRenewTime) {
+				} else if yym11 {
+					z.DecBinaryUnmarshal(x.LastRenewTime)
+				} else if !yym11 && z.IsJSONHandle() {
+					z.DecJSONUnmarshal(x.LastRenewTime)
+				} else {
+					z.DecFallback(x.LastRenewTime, false)
+				}
+			}
+		case "lastRotationTime":
+			if r.TryDecodeAsNil() {
+				if x.LastRotationTime != nil {
+					x.LastRotationTime = nil
+				}
+			} else {
+				if x.LastRotationTime == nil {
+					x.LastRotationTime = new(pkg2_unversioned.Time)
+				}
+				yym13 := z.DecBinary()
+				_ = yym13
+				if false {
+				} else if z.HasExtensions() && z.DecExt(x.LastRotationTime) {
+				} else if yym13 {
+					z.DecBinaryUnmarshal(x.LastRotationTime)
+				} else if !yym13 && z.IsJSONHandle() {
+					z.DecJSONUnmarshal(x.LastRotationTime)
+				} else {
+					z.DecFallback(x.LastRotationTime, false)
+				}
+			}
+		case "lastError":
+			if r.TryDecodeAsNil() {
+				x.LastError = ""
+			} else {
+				yyv14 := &x.LastError
+				yym15 := z.DecBinary()
+				_ = yym15
+				if false {
+				} else {
+					*((*string)(yyv14)) = r.DecodeString()
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *SecretClaimStatus) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj16 int
+	var yyb16 bool
+	var yyhl16 bool = l >= 0
+	yyj16++
+	if yyhl16 {
+		yyb16 = yyj16 > l
+	} else {
+		yyb16 = r.CheckBreak()
+	}
+	if yyb16 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.ObservedGeneration = 0
+	} else {
+		yyv17 := &x.ObservedGeneration
+		yym18 := z.DecBinary()
+		_ = yym18
+		if false {
+		} else {
+			*((*int64)(yyv17)) = int64(r.DecodeInt(64))
+		}
+	}
+	yyj16++
+	if yyhl16 {
+		yyb16 = yyj16 > l
+	} else {
+		yyb16 = r.CheckBreak()
+	}
+	if yyb16 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Conditions = nil
+	} else {
+		yyv19 := &x.Conditions
+		yym20 := z.DecBinary()
+		_ = yym20
+		if false {
+		} else {
+			h.decSliceSecretClaimCondition((*[]SecretClaimCondition)(yyv19), d)
+		}
+	}
+	yyj16++
+	if yyhl16 {
+		yyb16 = yyj16 > l
+	} else {
+		yyb16 = r.CheckBreak()
+	}
+	if yyb16 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		if x.LeaseExpiration != nil {
+			x.LeaseExpiration = nil
+		}
+	} else {
+		if x.LeaseExpiration == nil {
+			x.LeaseExpiration = new(pkg2_unversioned.Time)
+		}
+		yym22 := z.DecBinary()
+		_ = yym22
+		if false {
+		} else if z.HasExtensions() && z.DecExt(x.LeaseExpiration) {
+		} else if yym22 {
+			z.DecBinaryUnmarshal(x.LeaseExpiration)
+		} else if !yym22 && z.IsJSONHandle() {
+			z.DecJSONUnmarshal(x.LeaseExpiration)
+		} else {
+			z.DecFallback(x.LeaseExpiration, false)
+		}
+	}
+	yyj16++
+	if yyhl16 {
+		yyb16 = yyj16 > l
+	} else {
+		yyb16 = r.CheckBreak()
+	}
+	if yyb16 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		if x.LastRenewTime != nil {
+			x.LastRenewTime = nil
+		}
+	} else {
+		if x.LastRenewTime == nil {
+			x.LastRenewTime = new(pkg2_unversioned.Time)
+		}
+		yym24 := z.DecBinary()
+		_ = yym24
+		if false {
+		} else if z.HasExtensions() && z.DecExt(x.LastRenewTime) {
+		} else if yym24 {
+			z.DecBinaryUnmarshal(x.LastRenewTime)
+		} else if !yym24 && z.IsJSONHandle() {
+			z.DecJSONUnmarshal(x.LastRenewTime)
+		} else {
+			z.DecFallback(x.LastRenewTime, false)
+		}
+	}
+	yyj16++
+	if yyhl16 {
+		yyb16 = yyj16 > l
+	} else {
+		yyb16 = r.CheckBreak()
+	}
+	if yyb16 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		if x.LastRotationTime != nil {
+			x.LastRotationTime = nil
+		}
+	} else {
+		if x.LastRotationTime == nil {
+			x.LastRotationTime = new(pkg2_unversioned.Time)
+		}
+		yym26 := z.DecBinary()
+		_ = yym26
+		if false {
+		} else if z.HasExtensions() && z.DecExt(x.LastRotationTime) {
+		} else if yym26 {
+			z.DecBinaryUnmarshal(x.LastRotationTime)
+		} else if !yym26 && z.IsJSONHandle() {
+			z.DecJSONUnmarshal(x.LastRotationTime)
+		} else {
+			z.DecFallback(x.LastRotationTime, false)
+		}
+	}
+	yyj16++
+	if yyhl16 {
+		yyb16 = yyj16 > l
+	} else {
+		yyb16 = r.CheckBreak()
+	}
+	if yyb16 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.LastError = ""
+	} else {
+		yyv27 := &x.LastError
+		yym28 := z.DecBinary()
+		_ = yym28
+		if false {
+		} else {
+			*((*string)(yyv27)) = r.DecodeString()
+		}
+	}
+	for {
+		yyj16++
+		if yyhl16 {
+			yyb16 = yyj16 > l
+		} else {
+			yyb16 = r.CheckBreak()
+		}
+		if yyb16 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj16-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x *SecretClaim) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yym1 := z.EncBinary()
+		_ = yym1
+		if false {
+		} else if z.HasExtensions() && z.EncExt(x) {
+		} else {
+			yysep2 := !z.EncBinary()
+			yy2arr2 := z.EncBasicHandle().StructToArray
+			var yyq2 [5]bool
+			_, _, _ = yysep2, yyq2, yy2arr2
+			const yyr2 bool = false
+			yyq2[0] = x.Kind != ""
+			yyq2[1] = x.APIVersion != ""
+			yyq2[2] = true
+			yyq2[4] = true
+			var yynn2 int
+			if yyr2 || yy2arr2 {
+				r.EncodeArrayStart(5)
+			} else {
+				yynn2 = 1
+				for _, b := range yyq2 {
+					if b {
+						yynn2++
+					}
+				}
+				r.EncodeMapStart(yynn2)
+				yynn2 = 0
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[0] {
+					yym4 := z.EncBinary()
+					_ = yym4
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[0] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("kind"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym5 := z.EncBinary()
+					_ = yym5
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[1] {
+					yym7 := z.EncBinary()
+					_ = yym7
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[1] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("apiVersion"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym8 := z.EncBinary()
+					_ = yym8
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[2] {
+					yy10 := &x.ObjectMeta
+					yy10.CodecEncodeSelf(e)
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[2] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("metadata"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yy12 := &x.ObjectMeta
+					yy12.CodecEncodeSelf(e)
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				yy15 := &x.Spec
+				yy15.CodecEncodeSelf(e)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("spec"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				yy17 := &x.Spec
+				yy17.CodecEncodeSelf(e)
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[4] {
+					yy20 := &x.Status
+					yy20.CodecEncodeSelf(e)
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[4] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("status"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yy22 := &x.Status
+					yy22.CodecEncodeSelf(e)
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
+			}
+		}
+	}
+}
+
+func (x *SecretClaim) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	yym1 := z.DecBinary()
+	_ = yym1
+	if false {
+	} else if z.HasExtensions() && z.DecExt(x) {
+	} else {
+		yyct2 := r.ContainerType()
+		if yyct2 == codecSelferValueTypeMap6836 {
+			yyl2 := r.ReadMapStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+			} else {
+				x.codecDecodeSelfFromMap(yyl2, d)
+			}
+		} else if yyct2 == codecSelferValueTypeArray6836 {
+			yyl2 := r.ReadArrayStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				x.codecDecodeSelfFromArray(yyl2, d)
+			}
+		} else {
+			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
+		}
+	}
+}
+
+func (x *SecretClaim) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "kind":
+			if r.TryDecodeAsNil() {
+				x.Kind = ""
+			} else {
+				yyv4 := &x.Kind
+				yym5 := z.DecBinary()
+				_ = yym5
+				if false {
+				} else {
+					*((*string)(yyv4)) = r.DecodeString()
+				}
+			}
+		case "apiVersion":
+			if r.TryDecodeAsNil() {
+				x.APIVersion = ""
+			} else {
+				yyv6 := &x.APIVersion
+				yym7 := z.DecBinary()
+				_ = yym7
+				if false {
+				} else {
+					*((*string)(yyv6)) = r.DecodeString()
+				}
+			}
+		case "metadata":
+			if r.TryDecodeAsNil() {
+				x.ObjectMeta = pkg3_api.ObjectMeta{}
+			} else {
+				yyv8 := &x.ObjectMeta
+				yyv8.CodecDecodeSelf(d)
+			}
+		case "spec":
+			if r.TryDecodeAsNil() {
+				x.Spec = SecretSpec{}
+			} else {
+				yyv9 := &x.Spec
+				yyv9.CodecDecodeSelf(d)
+			}
+		case "status":
+			if r.TryDecodeAsNil() {
+				x.Status = SecretClaimStatus{}
+			} else {
+				yyv10 := &x.Status
+				yyv10.CodecDecodeSelf(d)
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *SecretClaim) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj11 int
+	var yyb11 bool
+	var yyhl11 bool = l >= 0
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = r.CheckBreak()
+	}
+	if yyb11 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Kind = ""
+	} else {
+		yyv12 := &x.Kind
+		yym13 := z.DecBinary()
+		_ = yym13
+		if false {
+		} else {
+			*((*string)(yyv12)) = r.DecodeString()
+		}
+	}
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = r.CheckBreak()
+	}
+	if yyb11 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.APIVersion = ""
+	} else {
+		yyv14 := &x.APIVersion
+		yym15 := z.DecBinary()
+		_ = yym15
+		if false {
+		} else {
+			*((*string)(yyv14)) = r.DecodeString()
+		}
+	}
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = r.CheckBreak()
+	}
+	if yyb11 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.ObjectMeta = pkg3_api.ObjectMeta{}
+	} else {
+		yyv16 := &x.ObjectMeta
+		yyv16.CodecDecodeSelf(d)
+	}
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = r.CheckBreak()
+	}
+	if yyb11 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Spec = SecretSpec{}
+	} else {
+		yyv17 := &x.Spec
+		yyv17.CodecDecodeSelf(d)
+	}
+	yyj11++
+	if yyhl11 {
+		yyb11 = yyj11 > l
+	} else {
+		yyb11 = r.CheckBreak()
+	}
+	if yyb11 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Status = SecretClaimStatus{}
+	} else {
+		yyv18 := &x.Status
+		yyv18.CodecDecodeSelf(d)
+	}
+	for {
+		yyj11++
+		if yyhl11 {
+			yyb11 = yyj11 > l
+		} else {
+			yyb11 = r.CheckBreak()
+		}
+		if yyb11 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj11-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x *SecretClaimList) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yym1 := z.EncBinary()
+		_ = yym1
+		if false {
+		} else if z.HasExtensions() && z.EncExt(x) {
+		} else {
+			yysep2 := !z.EncBinary()
+			yy2arr2 := z.EncBasicHandle().StructToArray
+			var yyq2 [4]bool
+			_, _, _ = yysep2, yyq2, yy2arr2
+			const yyr2 bool = false
+			yyq2[0] = x.Kind != ""
+			yyq2[1] = x.APIVersion != ""
+			yyq2[2] = true
+			var yynn2 int
+			if yyr2 || yy2arr2 {
+				r.EncodeArrayStart(4)
+			} else {
+				yynn2 = 1
+				for _, b := range yyq2 {
+					if b {
+						yynn2++
+					}
+				}
+				r.EncodeMapStart(yynn2)
+				yynn2 = 0
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[0] {
+					yym4 := z.EncBinary()
+					_ = yym4
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[0] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("kind"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym5 := z.EncBinary()
+					_ = yym5
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[1] {
+					yym7 := z.EncBinary()
+					_ = yym7
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[1] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("apiVersion"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym8 := z.EncBinary()
+					_ = yym8
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[2] {
+					yy10 := &x.ListMeta
+					yym11 := z.EncBinary()
+					_ = yym11
+					if false {
+					} else if z.HasExtensions() && z.EncExt(yy10) {
+					} else {
+						z.EncFallback(yy10)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[2] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("metadata"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yy12 := &x.ListMeta
+					yym13 := z.EncBinary()
+					_ = yym13
+					if false {
+					} else if z.HasExtensions() && z.EncExt(yy12) {
+					} else {
+						z.EncFallback(yy12)
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if x.Items == nil {
+					r.EncodeNil()
+				} else {
+					yym15 := z.EncBinary()
+					_ = yym15
+					if false {
+					} else {
+						h.encSliceSecretClaim(([]SecretClaim)(x.Items), e)
+					}
+				}
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("items"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				if x.Items == nil {
+					r.EncodeNil()
+				} else {
+					yym16 := z.EncBinary()
+					_ = yym16
+					if false {
+					} else {
+						h.encSliceSecretClaim(([]SecretClaim)(x.Items), e)
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
+			}
+		}
+	}
+}
+
+func (x *SecretClaimList) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	yym1 := z.DecBinary()
+	_ = yym1
+	if false {
+	} else if z.HasExtensions() && z.DecExt(x) {
+	} else {
+		yyct2 := r.ContainerType()
+		if yyct2 == codecSelferValueTypeMap6836 {
+			yyl2 := r.ReadMapStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+			} else {
+				x.codecDecodeSelfFromMap(yyl2, d)
+			}
+		} else if yyct2 == codecSelferValueTypeArray6836 {
+			yyl2 := r.ReadArrayStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				x.codecDecodeSelfFromArray(yyl2, d)
+			}
+		} else {
+			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
+		}
+	}
+}
+
+func (x *SecretClaimList) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "kind":
+			if r.TryDecodeAsNil() {
+				x.Kind = ""
+			} else {
+				yyv4 := &x.Kind
+				yym5 := z.DecBinary()
+				_ = yym5
+				if false {
+				} else {
+					*((*string)(yyv4)) = r.DecodeString()
+				}
+			}
+		case "apiVersion":
+			if r.TryDecodeAsNil() {
+				x.APIVersion = ""
+			} else {
+				yyv6 := &x.APIVersion
+				yym7 := z.DecBinary()
+				_ = yym7
+				if false {
+				} else {
+					*((*string)(yyv6)) = r.DecodeString()
+				}
+			}
+		case "metadata":
+			if r.TryDecodeAsNil() {
+				x.ListMeta = pkg2_unversioned.ListMeta{}
+			} else {
+				yyv8 := &x.ListMeta
+				yym9 := z.DecBinary()
+				_ = yym9
+				if false {
+				} else if z.HasExtensions() && z.DecExt(yyv8) {
+				} else {
+					z.DecFallback(yyv8, false)
+				}
+			}
+		case "items":
+			if r.TryDecodeAsNil() {
+				x.Items = nil
+			} else {
+				yyv10 := &x.Items
+				yym11 := z.DecBinary()
+				_ = yym11
+				if false {
+				} else {
+					h.decSliceSecretClaim((*[]SecretClaim)(yyv10), d)
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *SecretClaimList) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj12 int
+	var yyb12 bool
+	var yyhl12 bool = l >= 0
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = r.CheckBreak()
+	}
+	if yyb12 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Kind = ""
+	} else {
+		yyv13 := &x.Kind
+		yym14 := z.DecBinary()
+		_ = yym14
+		if false {
+		} else {
+			*((*string)(yyv13)) = r.DecodeString()
+		}
+	}
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = r.CheckBreak()
+	}
+	if yyb12 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.APIVersion = ""
+	} else {
+		yyv15 := &x.APIVersion
+		yym16 := z.DecBinary()
+		_ = yym16
+		if false {
+		} else {
+			*((*string)(yyv15)) = r.DecodeString()
+		}
+	}
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = r.CheckBreak()
+	}
+	if yyb12 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.ListMeta = pkg2_unversioned.ListMeta{}
+	} else {
+		yyv17 := &x.ListMeta
+		yym18 := z.DecBinary()
+		_ = yym18
+		if false {
+		} else if z.HasExtensions() && z.DecExt(yyv17) {
+		} else {
+			z.DecFallback(yyv17, false)
+		}
+	}
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = r.CheckBreak()
+	}
+	if yyb12 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Items = nil
+	} else {
+		yyv19 := &x.Items
+		yym20 := z.DecBinary()
+		_ = yym20
+		if false {
+		} else {
+			h.decSliceSecretClaim((*[]SecretClaim)(yyv19), d)
+		}
+	}
+	for {
+		yyj12++
+		if yyhl12 {
+			yyb12 = yyj12 > l
+		} else {
+			yyb12 = r.CheckBreak()
+		}
+		if yyb12 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj12-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x *IngressTLS) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yym1 := z.EncBinary()
+		_ = yym1
+		if false {
+		} else if z.HasExtensions() && z.EncExt(x) {
+		} else {
+			yysep2 := !z.EncBinary()
+			yy2arr2 := z.EncBasicHandle().StructToArray
+			var yyq2 [2]bool
+			_, _, _ = yysep2, yyq2, yy2arr2
+			const yyr2 bool = false
+			yyq2[0] = len(x.Hosts) != 0
+			yyq2[1] = x.SecretName != ""
+			var yynn2 int
+			if yyr2 || yy2arr2 {
+				r.EncodeArrayStart(2)
+			} else {
+				yynn2 = 0
+				for _, b := range yyq2 {
+					if b {
+						yynn2++
+					}
+				}
+				r.EncodeMapStart(yynn2)
+				yynn2 = 0
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[0] {
+					if x.Hosts == nil {
+						r.EncodeNil()
+					} else {
+						yym4 := z.EncBinary()
+						_ = yym4
+						if false {
+						} else {
+							z.F.EncSliceStringV(x.Hosts, false, e)
+						}
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[0] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("hosts"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					if x.Hosts == nil {
+						r.EncodeNil()
+					} else {
+						yym5 := z.EncBinary()
+						_ = yym5
+						if false {
+						} else {
+							z.F.EncSliceStringV(x.Hosts, false, e)
+						}
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[1] {
+					yym7 := z.EncBinary()
+					_ = yym7
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.SecretName))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[1] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("secretName"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym8 := z.EncBinary()
+					_ = yym8
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.SecretName))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
+			}
+		}
+	}
+}
+
+func (x *IngressTLS) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	yym1 := z.DecBinary()
+	_ = yym1
+	if false {
+	} else if z.HasExtensions() && z.DecExt(x) {
+	} else {
+		yyct2 := r.ContainerType()
+		if yyct2 == codecSelferValueTypeMap6836 {
+			yyl2 := r.ReadMapStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+			} else {
+				x.codecDecodeSelfFromMap(yyl2, d)
+			}
+		} else if yyct2 == codecSelferValueTypeArray6836 {
+			yyl2 := r.ReadArrayStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				x.codecDecodeSelfFromArray(yyl2, d)
+			}
+		} else {
+			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
+		}
+	}
+}
+
+func (x *IngressTLS) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "hosts":
+			if r.TryDecodeAsNil() {
+				x.Hosts = nil
+			} else {
+				yyv4 := &x.Hosts
+				yym5 := z.DecBinary()
+				_ = yym5
+				if false {
+				} else {
+					z.F.DecSliceStringX(yyv4, false, d)
+				}
+			}
+		case "secretName":
+			if r.TryDecodeAsNil() {
+				x.SecretName = ""
+			} else {
+				yyv6 := &x.SecretName
+				yym7 := z.DecBinary()
+				_ = yym7
+				if false {
+				} else {
+					*((*string)(yyv6)) = r.DecodeString()
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *IngressTLS) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj8 int
+	var yyb8 bool
+	var yyhl8 bool = l >= 0
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = r.CheckBreak()
+	}
+	if yyb8 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Hosts = nil
+	} else {
+		yyv9 := &x.Hosts
+		yym10 := z.DecBinary()
+		_ = yym10
+		if false {
+		} else {
+			z.F.DecSliceStringX(yyv9, false, d)
+		}
+	}
+	yyj8++
+	if yyhl8 {
+		yyb8 = yyj8 > l
+	} else {
+		yyb8 = r.CheckBreak()
+	}
+	if yyb8 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.SecretName = ""
+	} else {
+		yyv11 := &x.SecretName
+		yym12 := z.DecBinary()
+		_ = yym12
+		if false {
+		} else {
+			*((*string)(yyv11)) = r.DecodeString()
+		}
+	}
+	for {
+		yyj8++
+		if yyhl8 {
+			yyb8 = yyj8 > l
+		} else {
+			yyb8 = r.CheckBreak()
+		}
+		if yyb8 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj8-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x *IngressSpec) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yym1 := z.EncBinary()
+		_ = yym1
+		if false {
+		} else if z.HasExtensions() && z.EncExt(x) {
+		} else {
+			yysep2 := !z.EncBinary()
+			yy2arr2 := z.EncBasicHandle().StructToArray
+			var yyq2 [1]bool
+			_, _, _ = yysep2, yyq2, yy2arr2
+			const yyr2 bool = false
+			yyq2[0] = len(x.TLS) != 0
+			var yynn2 int
+			if yyr2 || yy2arr2 {
+				r.EncodeArrayStart(1)
+			} else {
+				yynn2 = 0
+				for _, b := range yyq2 {
+					if b {
+						yynn2++
+					}
+				}
+				r.EncodeMapStart(yynn2)
+				yynn2 = 0
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[0] {
+					if x.TLS == nil {
+						r.EncodeNil()
+					} else {
+						yym4 := z.EncBinary()
+						_ = yym4
+						if false {
+						} else {
+							h.encSliceIngressTLS(([]IngressTLS)(x.TLS), e)
+						}
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[0] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("tls"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					if x.TLS == nil {
+						r.EncodeNil()
+					} else {
+						yym5 := z.EncBinary()
+						_ = yym5
+						if false {
+						} else {
+							h.encSliceIngressTLS(([]IngressTLS)(x.TLS), e)
+						}
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
+			}
+		}
+	}
+}
+
+func (x *IngressSpec) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	yym1 := z.DecBinary()
+	_ = yym1
+	if false {
+	} else if z.HasExtensions() && z.DecExt(x) {
+	} else {
+		yyct2 := r.ContainerType()
+		if yyct2 == codecSelferValueTypeMap6836 {
+			yyl2 := r.ReadMapStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+			} else {
+				x.codecDecodeSelfFromMap(yyl2, d)
+			}
+		} else if yyct2 == codecSelferValueTypeArray6836 {
+			yyl2 := r.ReadArrayStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				x.codecDecodeSelfFromArray(yyl2, d)
+			}
+		} else {
+			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
+		}
+	}
+}
+
+func (x *IngressSpec) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "tls":
+			if r.TryDecodeAsNil() {
+				x.TLS = nil
+			} else {
+				yyv4 := &x.TLS
+				yym5 := z.DecBinary()
+				_ = yym5
+				if false {
+				} else {
+					h.decSliceIngressTLS((*[]IngressTLS)(yyv4), d)
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *IngressSpec) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj6 int
+	var yyb6 bool
+	var yyhl6 bool = l >= 0
+	yyj6++
+	if yyhl6 {
+		yyb6 = yyj6 > l
+	} else {
+		yyb6 = r.CheckBreak()
+	}
+	if yyb6 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.TLS = nil
+	} else {
+		yyv7 := &x.TLS
+		yym8 := z.DecBinary()
+		_ = yym8
+		if false {
+		} else {
+			h.decSliceIngressTLS((*[]IngressTLS)(yyv7), d)
+		}
+	}
+	for {
+		yyj6++
+		if yyhl6 {
+			yyb6 = yyj6 > l
+		} else {
+			yyb6 = r.CheckBreak()
+		}
+		if yyb6 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj6-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x *Ingress) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yym1 := z.EncBinary()
+		_ = yym1
+		if false {
+		} else if z.HasExtensions() && z.EncExt(x) {
+		} else {
+			yysep2 := !z.EncBinary()
+			yy2arr2 := z.EncBasicHandle().StructToArray
+			var yyq2 [4]bool
+			_, _, _ = yysep2, yyq2, yy2arr2
+			const yyr2 bool = false
+			yyq2[0] = x.Kind != ""
+			yyq2[1] = x.APIVersion != ""
+			yyq2[2] = true
+			var yynn2 int
+			if yyr2 || yy2arr2 {
+				r.EncodeArrayStart(4)
+			} else {
+				yynn2 = 1
+				for _, b := range yyq2 {
+					if b {
+						yynn2++
+					}
+				}
+				r.EncodeMapStart(yynn2)
+				yynn2 = 0
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[0] {
+					yym4 := z.EncBinary()
+					_ = yym4
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[0] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("kind"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym5 := z.EncBinary()
+					_ = yym5
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[1] {
+					yym7 := z.EncBinary()
+					_ = yym7
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[1] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("apiVersion"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym8 := z.EncBinary()
+					_ = yym8
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[2] {
+					yy10 := &x.ObjectMeta
+					yy10.CodecEncodeSelf(e)
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[2] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("metadata"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yy12 := &x.ObjectMeta
+					yy12.CodecEncodeSelf(e)
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				yy15 := &x.Spec
+				yy15.CodecEncodeSelf(e)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("spec"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				yy17 := &x.Spec
+				yy17.CodecEncodeSelf(e)
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
+			}
+		}
+	}
+}
+
+func (x *Ingress) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	yym1 := z.DecBinary()
+	_ = yym1
+	if false {
+	} else if z.HasExtensions() && z.DecExt(x) {
+	} else {
+		yyct2 := r.ContainerType()
+		if yyct2 == codecSelferValueTypeMap6836 {
+			yyl2 := r.ReadMapStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+			} else {
+				x.codecDecodeSelfFromMap(yyl2, d)
+			}
+		} else if yyct2 == codecSelferValueTypeArray6836 {
+			yyl2 := r.ReadArrayStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				x.codecDecodeSelfFromArray(yyl2, d)
+			}
+		} else {
+			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
+		}
+	}
+}
+
+func (x *Ingress) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "kind":
+			if r.TryDecodeAsNil() {
+				x.Kind = ""
+			} else {
+				yyv4 := &x.Kind
+				yym5 := z.DecBinary()
+				_ = yym5
+				if false {
+				} else {
+					*((*string)(yyv4)) = r.DecodeString()
+				}
+			}
+		case "apiVersion":
+			if r.TryDecodeAsNil() {
+				x.APIVersion = ""
+			} else {
+				yyv6 := &x.APIVersion
+				yym7 := z.DecBinary()
+				_ = yym7
+				if false {
+				} else {
+					*((*string)(yyv6)) = r.DecodeString()
+				}
+			}
+		case "metadata":
+			if r.TryDecodeAsNil() {
+				x.ObjectMeta = pkg3_api.ObjectMeta{}
+			} else {
+				yyv8 := &x.ObjectMeta
+				yyv8.CodecDecodeSelf(d)
+			}
+		case "spec":
+			if r.TryDecodeAsNil() {
+				x.Spec = IngressSpec{}
+			} else {
+				yyv9 := &x.Spec
+				yyv9.CodecDecodeSelf(d)
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *Ingress) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj10 int
+	var yyb10 bool
+	var yyhl10 bool = l >= 0
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = r.CheckBreak()
+	}
+	if yyb10 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Kind = ""
+	} else {
+		yyv11 := &x.Kind
+		yym12 := z.DecBinary()
+		_ = yym12
+		if false {
+		} else {
+			*((*string)(yyv11)) = r.DecodeString()
+		}
+	}
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = r.CheckBreak()
+	}
+	if yyb10 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.APIVersion = ""
+	} else {
+		yyv13 := &x.APIVersion
+		yym14 := z.DecBinary()
+		_ = yym14
+		if false {
+		} else {
+			*((*string)(yyv13)) = r.DecodeString()
+		}
+	}
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = r.CheckBreak()
+	}
+	if yyb10 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.ObjectMeta = pkg3_api.ObjectMeta{}
+	} else {
+		yyv15 := &x.ObjectMeta
+		yyv15.CodecDecodeSelf(d)
+	}
+	yyj10++
+	if yyhl10 {
+		yyb10 = yyj10 > l
+	} else {
+		yyb10 = r.CheckBreak()
+	}
+	if yyb10 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Spec = IngressSpec{}
+	} else {
+		yyv16 := &x.Spec
+		yyv16.CodecDecodeSelf(d)
+	}
+	for {
+		yyj10++
+		if yyhl10 {
+			yyb10 = yyj10 > l
+		} else {
+			yyb10 = r.CheckBreak()
+		}
+		if yyb10 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj10-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x *IngressList) CodecEncodeSelf(e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	if x == nil {
+		r.EncodeNil()
+	} else {
+		yym1 := z.EncBinary()
+		_ = yym1
+		if false {
+		} else if z.HasExtensions() && z.EncExt(x) {
+		} else {
+			yysep2 := !z.EncBinary()
+			yy2arr2 := z.EncBasicHandle().StructToArray
+			var yyq2 [4]bool
+			_, _, _ = yysep2, yyq2, yy2arr2
+			const yyr2 bool = false
+			yyq2[0] = x.Kind != ""
+			yyq2[1] = x.APIVersion != ""
+			yyq2[2] = true
+			var yynn2 int
+			if yyr2 || yy2arr2 {
+				r.EncodeArrayStart(4)
+			} else {
+				yynn2 = 1
+				for _, b := range yyq2 {
+					if b {
+						yynn2++
+					}
+				}
+				r.EncodeMapStart(yynn2)
+				yynn2 = 0
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[0] {
+					yym4 := z.EncBinary()
+					_ = yym4
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[0] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("kind"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym5 := z.EncBinary()
+					_ = yym5
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.Kind))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[1] {
+					yym7 := z.EncBinary()
+					_ = yym7
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+					}
+				} else {
+					r.EncodeString(codecSelferC_UTF86836, "")
+				}
+			} else {
+				if yyq2[1] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("apiVersion"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yym8 := z.EncBinary()
+					_ = yym8
+					if false {
+					} else {
+						r.EncodeString(codecSelferC_UTF86836, string(x.APIVersion))
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if yyq2[2] {
+					yy10 := &x.ListMeta
+					yym11 := z.EncBinary()
+					_ = yym11
+					if false {
+					} else if z.HasExtensions() && z.EncExt(yy10) {
+					} else {
+						z.EncFallback(yy10)
+					}
+				} else {
+					r.EncodeNil()
+				}
+			} else {
+				if yyq2[2] {
+					z.EncSendContainerState(codecSelfer_containerMapKey6836)
+					r.EncodeString(codecSelferC_UTF86836, string("metadata"))
+					z.EncSendContainerState(codecSelfer_containerMapValue6836)
+					yy12 := &x.ListMeta
+					yym13 := z.EncBinary()
+					_ = yym13
+					if false {
+					} else if z.HasExtensions() && z.EncExt(yy12) {
+					} else {
+						z.EncFallback(yy12)
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+				if x.Items == nil {
+					r.EncodeNil()
+				} else {
+					yym15 := z.EncBinary()
+					_ = yym15
+					if false {
+					} else {
+						h.encSliceIngress(([]Ingress)(x.Items), e)
+					}
+				}
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapKey6836)
+				r.EncodeString(codecSelferC_UTF86836, string("items"))
+				z.EncSendContainerState(codecSelfer_containerMapValue6836)
+				if x.Items == nil {
+					r.EncodeNil()
+				} else {
+					yym16 := z.EncBinary()
+					_ = yym16
+					if false {
+					} else {
+						h.encSliceIngress(([]Ingress)(x.Items), e)
+					}
+				}
+			}
+			if yyr2 || yy2arr2 {
+				z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				z.EncSendContainerState(codecSelfer_containerMapEnd6836)
+			}
+		}
+	}
+}
+
+func (x *IngressList) CodecDecodeSelf(d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	yym1 := z.DecBinary()
+	_ = yym1
+	if false {
+	} else if z.HasExtensions() && z.DecExt(x) {
+	} else {
+		yyct2 := r.ContainerType()
+		if yyct2 == codecSelferValueTypeMap6836 {
+			yyl2 := r.ReadMapStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+			} else {
+				x.codecDecodeSelfFromMap(yyl2, d)
+			}
+		} else if yyct2 == codecSelferValueTypeArray6836 {
+			yyl2 := r.ReadArrayStart()
+			if yyl2 == 0 {
+				z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+			} else {
+				x.codecDecodeSelfFromArray(yyl2, d)
+			}
+		} else {
+			panic(codecSelferOnlyMapOrArrayEncodeToStructErr6836)
+		}
+	}
+}
+
+func (x *IngressList) codecDecodeSelfFromMap(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
+	_ = yys3Slc
+	var yyhl3 bool = l >= 0
+	for yyj3 := 0; ; yyj3++ {
+		if yyhl3 {
+			if yyj3 >= l {
+				break
+			}
+		} else {
+			if r.CheckBreak() {
+				break
+			}
+		}
+		z.DecSendContainerState(codecSelfer_containerMapKey6836)
+		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
+		yys3 := string(yys3Slc)
+		z.DecSendContainerState(codecSelfer_containerMapValue6836)
+		switch yys3 {
+		case "kind":
+			if r.TryDecodeAsNil() {
+				x.Kind = ""
+			} else {
+				yyv4 := &x.Kind
+				yym5 := z.DecBinary()
+				_ = yym5
+				if false {
+				} else {
+					*((*string)(yyv4)) = r.DecodeString()
+				}
+			}
+		case "apiVersion":
+			if r.TryDecodeAsNil() {
+				x.APIVersion = ""
+			} else {
+				yyv6 := &x.APIVersion
+				yym7 := z.DecBinary()
+				_ = yym7
+				if false {
+				} else {
+					*((*string)(yyv6)) = r.DecodeString()
+				}
+			}
+		case "metadata":
+			if r.TryDecodeAsNil() {
+				x.ListMeta = pkg2_unversioned.ListMeta{}
+			} else {
+				yyv8 := &x.ListMeta
+				yym9 := z.DecBinary()
+				_ = yym9
+				if false {
+				} else if z.HasExtensions() && z.DecExt(yyv8) {
+				} else {
+					z.DecFallback(yyv8, false)
+				}
+			}
+		case "items":
+			if r.TryDecodeAsNil() {
+				x.Items = nil
+			} else {
+				yyv10 := &x.Items
+				yym11 := z.DecBinary()
+				_ = yym11
+				if false {
+				} else {
+					h.decSliceIngress((*[]Ingress)(yyv10), d)
+				}
+			}
+		default:
+			z.DecStructFieldNotFound(-1, yys3)
+		} // end switch yys3
+	} // end for yyj3
+	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
+}
+
+func (x *IngressList) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+	var yyj12 int
+	var yyb12 bool
+	var yyhl12 bool = l >= 0
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = r.CheckBreak()
+	}
+	if yyb12 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Kind = ""
+	} else {
+		yyv13 := &x.Kind
+		yym14 := z.DecBinary()
+		_ = yym14
+		if false {
+		} else {
+			*((*string)(yyv13)) = r.DecodeString()
+		}
+	}
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = r.CheckBreak()
+	}
+	if yyb12 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.APIVersion = ""
+	} else {
+		yyv15 := &x.APIVersion
+		yym16 := z.DecBinary()
+		_ = yym16
+		if false {
+		} else {
+			*((*string)(yyv15)) = r.DecodeString()
+		}
+	}
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = r.CheckBreak()
+	}
+	if yyb12 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.ListMeta = pkg2_unversioned.ListMeta{}
+	} else {
+		yyv17 := &x.ListMeta
+		yym18 := z.DecBinary()
+		_ = yym18
+		if false {
+		} else if z.HasExtensions() && z.DecExt(yyv17) {
+		} else {
+			z.DecFallback(yyv17, false)
+		}
+	}
+	yyj12++
+	if yyhl12 {
+		yyb12 = yyj12 > l
+	} else {
+		yyb12 = r.CheckBreak()
+	}
+	if yyb12 {
+		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+		return
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+	if r.TryDecodeAsNil() {
+		x.Items = nil
+	} else {
+		yyv19 := &x.Items
+		yym20 := z.DecBinary()
+		_ = yym20
+		if false {
+		} else {
+			h.decSliceIngress((*[]Ingress)(yyv19), d)
+		}
+	}
+	for {
+		yyj12++
+		if yyhl12 {
+			yyb12 = yyj12 > l
+		} else {
+			yyb12 = r.CheckBreak()
+		}
+		if yyb12 {
+			break
+		}
+		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
+		z.DecStructFieldNotFound(yyj12-1, "")
+	}
+	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x codecSelfer6836) encSliceSecretClaimCondition(v []SecretClaimCondition, e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	r.EncodeArrayStart(len(v))
+	for _, yyv1 := range v {
+		z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+		yy2 := &yyv1
+		yy2.CodecEncodeSelf(e)
+	}
+	z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x codecSelfer6836) decSliceSecretClaimCondition(v *[]SecretClaimCondition, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []SecretClaimCondition{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else if yyl1 > 0 {
+		var yyrr1, yyrl1 int
+		var yyrt1 bool
+		_, _ = yyrl1, yyrt1
+		yyrr1 = yyl1 // len(yyv1)
+		if yyl1 > cap(yyv1) {
+
+			yyrg1 := len(yyv1) > 0
+			yyv21 := yyv1
+			yyrl1, yyrt1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 88)
+			if yyrt1 {
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]SecretClaimCondition, yyrl1)
+				}
+			} else {
+				yyv1 = make([]SecretClaimCondition, yyrl1)
+			}
+			yyc1 = true
+			yyrr1 = len(yyv1)
+			if yyrg1 {
+				copy(yyv1, yyv21)
+			}
+		} else if yyl1 != len(yyv1) {
+			yyv1 = yyv1[:yyl1]
+			yyc1 = true
+		}
+		yyj1 := 0
+		for ; yyj1 < yyrr1; yyj1++ {
+			yyh1.ElemContainerState(yyj1)
+			if r.TryDecodeAsNil() {
+				yyv1[yyj1] = SecretClaimCondition{}
+			} else {
+				yyv2 := &yyv1[yyj1]
+				yyv2.CodecDecodeSelf(d)
+			}
+
+		}
+		if yyrt1 {
+			for ; yyj1 < yyl1; yyj1++ {
+				yyv1 = append(yyv1, SecretClaimCondition{})
+				yyh1.ElemContainerState(yyj1)
+				if r.TryDecodeAsNil() {
+					yyv1[yyj1] = SecretClaimCondition{}
+				} else {
+					yyv3 := &yyv1[yyj1]
+					yyv3.CodecDecodeSelf(d)
+				}
+
+			}
+		}
+
+	} else {
+		yyj1 := 0
+		for ; !r.CheckBreak(); yyj1++ {
+
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, SecretClaimCondition{}) // var yyz1 SecretClaimCondition
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			if yyj1 < len(yyv1) {
+				if r.TryDecodeAsNil() {
+					yyv1[yyj1] = SecretClaimCondition{}
+				} else {
+					yyv4 := &yyv1[yyj1]
+					yyv4.CodecDecodeSelf(d)
+				}
+
+			} else {
+				z.DecSwallow()
+			}
+
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = []SecretClaimCondition{}
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer6836) encSliceSecretClaim(v []SecretClaim, e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	r.EncodeArrayStart(len(v))
+	for _, yyv1 := range v {
+		z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+		yy2 := &yyv1
+		yy2.CodecEncodeSelf(e)
+	}
+	z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x codecSelfer6836) decSliceSecretClaim(v *[]SecretClaim, d *codec1978.Decoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperDecoder(d)
+	_, _, _ = h, z, r
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []SecretClaim{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
+		}
+	} else if yyl1 > 0 {
+		var yyrr1, yyrl1 int
+		var yyrt1 bool
+		_, _ = yyrl1, yyrt1
+		yyrr1 = yyl1 // len(yyv1)
+		if yyl1 > cap(yyv1) {
+
+			yyrg1 := len(yyv1) > 0
+			yyv21 := yyv1
+			yyrl1, yyrt1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 480)
+			if yyrt1 {
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
+				} else {
+					yyv1 = make([]SecretClaim, yyrl1)
+				}
+			} else {
+				yyv1 = make([]SecretClaim, yyrl1)
+			}
+			yyc1 = true
+			yyrr1 = len(yyv1)
+			if yyrg1 {
+				copy(yyv1, yyv21)
+			}
+		} else if yyl1 != len(yyv1) {
+			yyv1 = yyv1[:yyl1]
+			yyc1 = true
+		}
+		yyj1 := 0
+		for ; yyj1 < yyrr1; yyj1++ {
+			yyh1.ElemContainerState(yyj1)
+			if r.TryDecodeAsNil() {
+				yyv1[yyj1] = SecretClaim{}
+			} else {
+				yyv2 := &yyv1[yyj1]
+				yyv2.CodecDecodeSelf(d)
+			}
+
+		}
+		if yyrt1 {
+			for ; yyj1 < yyl1; yyj1++ {
+				yyv1 = append(yyv1, SecretClaim{})
+				yyh1.ElemContainerState(yyj1)
+				if r.TryDecodeAsNil() {
+					yyv1[yyj1] = SecretClaim{}
+				} else {
+					yyv3 := &yyv1[yyj1]
+					yyv3.CodecDecodeSelf(d)
+				}
+
+			}
+		}
+
+	} else {
+		yyj1 := 0
+		for ; !r.CheckBreak(); yyj1++ {
+
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, SecretClaim{}) // var yyz1 SecretClaim
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			if yyj1 < len(yyv1) {
+				if r.TryDecodeAsNil() {
+					yyv1[yyj1] = SecretClaim{}
+				} else {
+					yyv4 := &yyv1[yyj1]
+					yyv4.CodecDecodeSelf(d)
+				}
+
+			} else {
+				z.DecSwallow()
+			}
+
+		}
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = []SecretClaim{}
+			yyc1 = true
+		}
+	}
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
+	}
+}
+
+func (x codecSelfer6836) encSliceIngressTLS(v []IngressTLS, e *codec1978.Encoder) {
+	var h codecSelfer6836
+	z, r := codec1978.GenHelperEncoder(e)
+	_, _, _ = h, z, r
+	r.EncodeArrayStart(len(v))
+	for _, yyv1 := range v {
+		z.EncSendContainerState(codecSelfer_containerArrayElem6836)
+		yy2 := &yyv1
+		yy2.CodecEncodeSelf(e)
+	}
+	z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
+}
+
+func (x codecSelfer6836) decSliceIngressTLS(v *[]IngressTLS, d *codec1978.Decoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperDecoder(d)
 	_, _, _ = h, z, r
-	var yys3Slc = z.DecScratchBuffer() // default slice to decode into
-	_ = yys3Slc
-	var yyhl3 bool = l >= 0
-	for yyj3 := 0; ; yyj3++ {
-		if yyhl3 {
-			if yyj3 >= l {
-				break
-			}
-		} else {
-			if r.CheckBreak() {
-				break
-			}
+
+	yyv1 := *v
+	yyh1, yyl1 := z.DecSliceHelperStart()
+	var yyc1 bool
+	_ = yyc1
+	if yyl1 == 0 {
+		if yyv1 == nil {
+			yyv1 = []IngressTLS{}
+			yyc1 = true
+		} else if len(yyv1) != 0 {
+			yyv1 = yyv1[:0]
+			yyc1 = true
 		}
-		z.DecSendContainerState(codecSelfer_containerMapKey6836)
-		yys3Slc = r.DecodeBytes(yys3Slc, true, true)
-		yys3 := string(yys3Slc)
-		z.DecSendContainerState(codecSelfer_containerMapValue6836)
-		switch yys3 {
-		case "kind":
-			if r.TryDecodeAsNil() {
-				x.Kind = ""
-			} else {
-				yyv4 := &x.Kind
-				yym5 := z.DecBinary()
-				_ = yym5
-				if false {
+	} else if yyl1 > 0 {
+		var yyrr1, yyrl1 int
+		var yyrt1 bool
+		_, _ = yyrl1, yyrt1
+		yyrr1 = yyl1 // len(yyv1)
+		if yyl1 > cap(yyv1) {
+
+			yyrg1 := len(yyv1) > 0
+			yyv21 := yyv1
+			yyrl1, yyrt1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 40)
+			if yyrt1 {
+				if yyrl1 <= cap(yyv1) {
+					yyv1 = yyv1[:yyrl1]
 				} else {
-					*((*string)(yyv4)) = r.DecodeString()
+					yyv1 = make([]IngressTLS, yyrl1)
 				}
-			}
-		case "apiVersion":
-			if r.TryDecodeAsNil() {
-				x.APIVersion = ""
 			} else {
-				yyv6 := &x.APIVersion
-				yym7 := z.DecBinary()
-				_ = yym7
-				if false {
-				} else {
-					*((*string)(yyv6)) = r.DecodeString()
-				}
+				yyv1 = make([]IngressTLS, yyrl1)
 			}
-		case "metadata":
+			yyc1 = true
+			yyrr1 = len(yyv1)
+			if yyrg1 {
+				copy(yyv1, yyv21)
+			}
+		} else if yyl1 != len(yyv1) {
+			yyv1 = yyv1[:yyl1]
+			yyc1 = true
+		}
+		yyj1 := 0
+		for ; yyj1 < yyrr1; yyj1++ {
+			yyh1.ElemContainerState(yyj1)
 			if r.TryDecodeAsNil() {
-				x.ListMeta = pkg2_unversioned.ListMeta{}
+				yyv1[yyj1] = IngressTLS{}
 			} else {
-				yyv8 := &x.ListMeta
-				yym9 := z.DecBinary()
-				_ = yym9
-				if false {
-				} else if z.HasExtensions() && z.DecExt(yyv8) {
+				yyv2 := &yyv1[yyj1]
+				yyv2.CodecDecodeSelf(d)
+			}
+
+		}
+		if yyrt1 {
+			for ; yyj1 < yyl1; yyj1++ {
+				yyv1 = append(yyv1, IngressTLS{})
+				yyh1.ElemContainerState(yyj1)
+				if r.TryDecodeAsNil() {
+					yyv1[yyj1] = IngressTLS{}
 				} else {
-					z.DecFallback(yyv8, false)
+					yyv3 := &yyv1[yyj1]
+					yyv3.CodecDecodeSelf(d)
 				}
+
 			}
-		case "items":
-			if r.TryDecodeAsNil() {
-				x.Items = nil
-			} else {
-				yyv10 := &x.Items
-				yym11 := z.DecBinary()
-				_ = yym11
-				if false {
+		}
+
+	} else {
+		yyj1 := 0
+		for ; !r.CheckBreak(); yyj1++ {
+
+			if yyj1 >= len(yyv1) {
+				yyv1 = append(yyv1, IngressTLS{}) // var yyz1 IngressTLS
+				yyc1 = true
+			}
+			yyh1.ElemContainerState(yyj1)
+			if yyj1 < len(yyv1) {
+				if r.TryDecodeAsNil() {
+					yyv1[yyj1] = IngressTLS{}
 				} else {
-					h.decSliceSecretClaim((*[]SecretClaim)(yyv10), d)
+					yyv4 := &yyv1[yyj1]
+					yyv4.CodecDecodeSelf(d)
 				}
+
+			} else {
+				z.DecSwallow()
 			}
-		default:
-			z.DecStructFieldNotFound(-1, yys3)
-		} // end switch yys3
-	} // end for yyj3
-	z.DecSendContainerState(codecSelfer_containerMapEnd6836)
-}
 
-func (x *SecretClaimList) codecDecodeSelfFromArray(l int, d *codec1978.Decoder) {
-	var h codecSelfer6836
-	z, r := codec1978.GenHelperDecoder(d)
-	_, _, _ = h, z, r
-	var yyj12 int
-	var yyb12 bool
-	var yyhl12 bool = l >= 0
-	yyj12++
-	if yyhl12 {
-		yyb12 = yyj12 > l
-	} else {
-		yyb12 = r.CheckBreak()
-	}
-	if yyb12 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.Kind = ""
-	} else {
-		yyv13 := &x.Kind
-		yym14 := z.DecBinary()
-		_ = yym14
-		if false {
-		} else {
-			*((*string)(yyv13)) = r.DecodeString()
-		}
-	}
-	yyj12++
-	if yyhl12 {
-		yyb12 = yyj12 > l
-	} else {
-		yyb12 = r.CheckBreak()
-	}
-	if yyb12 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.APIVersion = ""
-	} else {
-		yyv15 := &x.APIVersion
-		yym16 := z.DecBinary()
-		_ = yym16
-		if false {
-		} else {
-			*((*string)(yyv15)) = r.DecodeString()
-		}
-	}
-	yyj12++
-	if yyhl12 {
-		yyb12 = yyj12 > l
-	} else {
-		yyb12 = r.CheckBreak()
-	}
-	if yyb12 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.ListMeta = pkg2_unversioned.ListMeta{}
-	} else {
-		yyv17 := &x.ListMeta
-		yym18 := z.DecBinary()
-		_ = yym18
-		if false {
-		} else if z.HasExtensions() && z.DecExt(yyv17) {
-		} else {
-			z.DecFallback(yyv17, false)
 		}
-	}
-	yyj12++
-	if yyhl12 {
-		yyb12 = yyj12 > l
-	} else {
-		yyb12 = r.CheckBreak()
-	}
-	if yyb12 {
-		z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
-		return
-	}
-	z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-	if r.TryDecodeAsNil() {
-		x.Items = nil
-	} else {
-		yyv19 := &x.Items
-		yym20 := z.DecBinary()
-		_ = yym20
-		if false {
-		} else {
-			h.decSliceSecretClaim((*[]SecretClaim)(yyv19), d)
+		if yyj1 < len(yyv1) {
+			yyv1 = yyv1[:yyj1]
+			yyc1 = true
+		} else if yyj1 == 0 && yyv1 == nil {
+			yyv1 = []IngressTLS{}
+			yyc1 = true
 		}
 	}
-	for {
-		yyj12++
-		if yyhl12 {
-			yyb12 = yyj12 > l
-		} else {
-			yyb12 = r.CheckBreak()
-		}
-		if yyb12 {
-			break
-		}
-		z.DecSendContainerState(codecSelfer_containerArrayElem6836)
-		z.DecStructFieldNotFound(yyj12-1, "")
+	yyh1.End()
+	if yyc1 {
+		*v = yyv1
 	}
-	z.DecSendContainerState(codecSelfer_containerArrayEnd6836)
 }
 
-func (x codecSelfer6836) encSliceSecretClaim(v []SecretClaim, e *codec1978.Encoder) {
+func (x codecSelfer6836) encSliceIngress(v []Ingress, e *codec1978.Encoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperEncoder(e)
 	_, _, _ = h, z, r
@@ -1140,7 +4640,7 @@ func (x codecSelfer6836) encSliceSecretClaim(v []SecretClaim, e *codec1978.Encod
 	z.EncSendContainerState(codecSelfer_containerArrayEnd6836)
 }
 
-func (x codecSelfer6836) decSliceSecretClaim(v *[]SecretClaim, d *codec1978.Decoder) {
+func (x codecSelfer6836) decSliceIngress(v *[]Ingress, d *codec1978.Decoder) {
 	var h codecSelfer6836
 	z, r := codec1978.GenHelperDecoder(d)
 	_, _, _ = h, z, r
@@ -1151,7 +4651,7 @@ func (x codecSelfer6836) decSliceSecretClaim(v *[]SecretClaim, d *codec1978.Deco
 	_ = yyc1
 	if yyl1 == 0 {
 		if yyv1 == nil {
-			yyv1 = []SecretClaim{}
+			yyv1 = []Ingress{}
 			yyc1 = true
 		} else if len(yyv1) != 0 {
 			yyv1 = yyv1[:0]
@@ -1166,15 +4666,15 @@ func (x codecSelfer6836) decSliceSecretClaim(v *[]SecretClaim, d *codec1978.Deco
 
 			yyrg1 := len(yyv1) > 0
 			yyv21 := yyv1
-			yyrl1, yyrt1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 312)
+			yyrl1, yyrt1 = z.DecInferLen(yyl1, z.DecBasicHandle().MaxInitLen, 280)
 			if yyrt1 {
 				if yyrl1 <= cap(yyv1) {
 					yyv1 = yyv1[:yyrl1]
 				} else {
-					yyv1 = make([]SecretClaim, yyrl1)
+					yyv1 = make([]Ingress, yyrl1)
 				}
 			} else {
-				yyv1 = make([]SecretClaim, yyrl1)
+				yyv1 = make([]Ingress, yyrl1)
 			}
 			yyc1 = true
 			yyrr1 = len(yyv1)
@@ -1189,7 +4689,7 @@ func (x codecSelfer6836) decSliceSecretClaim(v *[]SecretClaim, d *codec1978.Deco
 		for ; yyj1 < yyrr1; yyj1++ {
 			yyh1.ElemContainerState(yyj1)
 			if r.TryDecodeAsNil() {
-				yyv1[yyj1] = SecretClaim{}
+				yyv1[yyj1] = Ingress{}
 			} else {
 				yyv2 := &yyv1[yyj1]
 				yyv2.CodecDecodeSelf(d)
@@ -1198,10 +4698,10 @@ func (x codecSelfer6836) decSliceSecretClaim(v *[]SecretClaim, d *codec1978.Deco
 		}
 		if yyrt1 {
 			for ; yyj1 < yyl1; yyj1++ {
-				yyv1 = append(yyv1, SecretClaim{})
+				yyv1 = append(yyv1, Ingress{})
 				yyh1.ElemContainerState(yyj1)
 				if r.TryDecodeAsNil() {
-					yyv1[yyj1] = SecretClaim{}
+					yyv1[yyj1] = Ingress{}
 				} else {
 					yyv3 := &yyv1[yyj1]
 					yyv3.CodecDecodeSelf(d)
@@ -1215,13 +4715,13 @@ func (x codecSelfer6836) decSliceSecretClaim(v *[]SecretClaim, d *codec1978.Deco
 		for ; !r.CheckBreak(); yyj1++ {
 
 			if yyj1 >= len(yyv1) {
-				yyv1 = append(yyv1, SecretClaim{}) // var yyz1 SecretClaim
+				yyv1 = append(yyv1, Ingress{}) // var yyz1 Ingress
 				yyc1 = true
 			}
 			yyh1.ElemContainerState(yyj1)
 			if yyj1 < len(yyv1) {
 				if r.TryDecodeAsNil() {
-					yyv1[yyj1] = SecretClaim{}
+					yyv1[yyj1] = Ingress{}
 				} else {
 					yyv4 := &yyv1[yyj1]
 					yyv4.CodecDecodeSelf(d)
@@ -1236,7 +4736,7 @@ func (x codecSelfer6836) decSliceSecretClaim(v *[]SecretClaim, d *codec1978.Deco
 			yyv1 = yyv1[:yyj1]
 			yyc1 = true
 		} else if yyj1 == 0 && yyv1 == nil {
-			yyv1 = []SecretClaim{}
+			yyv1 = []Ingress{}
 			yyc1 = true
 		}
 	}
@@ -0,0 +1,251 @@
+// Package leader elects a single leader among controller replicas.
+//
+// Replicas compete for a lock holding a leader record. The leader renews the
+// record every retry period; other replicas take over once they have seen the
+// same record unchanged for a lease duration. Expiry is measured from when a
+// replica observed the record, not from the times in it, so clock skew
+// between replicas doesn't matter.
+package leader
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/pkg/api/unversioned"
+	"k8s.io/client-go/pkg/util/wait"
+)
+
+// ErrLostLeadership is returned by Run when the lease could not be renewed
+// within the renew deadline.
+var ErrLostLeadership = errors.New("leader: lost leadership")
+
+var timeNow = time.Now
+
+// Config configures an Elector.
+type Config struct {
+	Lock Lock
+	// Identity identifies this replica in the leader record.
+	Identity string
+
+	// LeaseDuration is how long other replicas wait for a record to be
+	// renewed before taking over.
+	LeaseDuration time.Duration
+	// RenewDeadline is how long the leader tries to renew the record before
+	// giving up leadership. It must be shorter than LeaseDuration.
+	RenewDeadline time.Duration
+	// RetryPeriod is how often the record is acquired or renewed.
+	RetryPeriod time.Duration
+}
+
+// Elector runs a leader election.
+type Elector struct {
+	config Config
+
+	lock         sync.Mutex
+	observed     Record
+	observedTime time.Time
+	leader       bool
+}
+
+// NewElector returns an elector for config.
+func NewElector(config Config) (*Elector, error) {
+	if config.Lock == nil {
+		return nil, errors.New("leader: lock is required")
+	}
+	if config.Identity == "" {
+		return nil, errors.New("leader: identity is required")
+	}
+	if config.RetryPeriod <= 0 || config.RenewDeadline <= config.RetryPeriod || config.LeaseDuration <= config.RenewDeadline {
+		return nil, fmt.Errorf("leader: retry period (%s) < renew deadline (%s) < lease duration (%s) is required", config.RetryPeriod, config.RenewDeadline, config.LeaseDuration)
+	}
+	return &Elector{config: config}, nil
+}
+
+// Run blocks until this replica is the leader, then calls lead with a channel
+// closed when leadership ends. It returns once lead returns, with nil if stop
+// was closed or ErrLostLeadership if the record could not be renewed in time;
+// lead must stop all work when its channel is closed. After stop is closed the
+// lease is still renewed until lead returns, and can then be released.
+func (e *Elector) Run(stop <-chan struct{}, lead func(stop <-chan struct{})) error {
+	if !e.acquire(stop) {
+		return nil
+	}
+
+	leadStop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(leadStop)
+	}()
+
+	err := e.renew(stop)
+	close(leadStop)
+	if err == nil {
+		// keep the lease while lead finishes its work, so no other replica
+		// starts before it is done.
+		e.renew(done)
+	}
+	<-done
+	return err
+}
+
+// IsLeader returns whether this replica currently holds the lease.
+func (e *Elector) IsLeader() bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	return e.leader
+}
+
+// Release gives up the lease if this replica holds it, so another replica can
+// take over without waiting for it to expire.
+func (e *Elector) Release() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !e.leader {
+		return nil
+	}
+	current, err := e.config.Lock.Get()
+	if err != nil {
+		return err
+	}
+	if current == nil || current.HolderIdentity != e.config.Identity {
+		e.leader = false
+		return nil
+	}
+
+	now := unversioned.NewTime(timeNow())
+	record := Record{
+		LeaseDurationSeconds: 1,
+		AcquireTime:          now,
+		RenewTime:            now,
+		LeaderTransitions:    current.LeaderTransitions,
+	}
+	if err := e.config.Lock.Update(record); err != nil {
+		return err
+	}
+	e.leader = false
+	log.Printf("leader: released %s", e.config.Lock.Describe())
+	return nil
+}
+
+func (e *Elector) acquire(stop <-chan struct{}) bool {
+	log.Printf("leader: %s: waiting to acquire %s", e.config.Identity, e.config.Lock.Describe())
+	for {
+		if e.tryAcquireOrRenew() {
+			log.Printf("leader: %s: acquired %s", e.config.Identity, e.config.Lock.Describe())
+			return true
+		}
+
+		select {
+		case <-stop:
+			return false
+		case <-time.After(wait.Jitter(e.config.RetryPeriod, 0.2)):
+		}
+	}
+}
+
+func (e *Elector) renew(stop <-chan struct{}) error {
+	lastRenew := timeNow()
+	ticker := time.NewTicker(e.config.RetryPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+
+		if e.tryAcquireOrRenew() {
+			lastRenew = timeNow()
+			continue
+		}
+		if timeNow().Sub(lastRenew) > e.config.RenewDeadline {
+			e.lock.Lock()
+			e.leader = false
+			e.lock.Unlock()
+			log.Printf("leader: %s: failed to renew %s within %s", e.config.Identity, e.config.Lock.Describe(), e.config.RenewDeadline)
+			return ErrLostLeadership
+		}
+	}
+}
+
+// tryAcquireOrRenew takes the lock if it is free or expired, or renews it if
+// this replica holds it. It returns whether this replica holds the lock.
+func (e *Elector) tryAcquireOrRenew() bool {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	now := timeNow()
+	record := Record{
+		HolderIdentity:       e.config.Identity,
+		LeaseDurationSeconds: int(e.config.LeaseDuration / time.Second),
+		AcquireTime:          unversioned.NewTime(now),
+		RenewTime:            unversioned.NewTime(now),
+	}
+
+	current, err := e.config.Lock.Get()
+	if err != nil {
+		log.Printf("leader: failed to get %s: %s", e.config.Lock.Describe(), err.Error())
+		return false
+	}
+	if current == nil {
+		if err := e.config.Lock.Create(record); err != nil {
+			log.Printf("leader: failed to create %s: %s", e.config.Lock.Describe(), err.Error())
+			return false
+		}
+		e.observe(record, now)
+		e.leader = true
+		return true
+	}
+
+	if !recordEqual(*current, e.observed) {
+		e.observe(*current, now)
+	}
+	held := current.HolderIdentity == e.config.Identity
+	if !held && current.HolderIdentity != "" && e.observedTime.Add(e.leaseDuration(*current)).After(now) {
+		e.leader = false
+		return false
+	}
+
+	if held {
+		record.AcquireTime = current.AcquireTime
+		record.LeaderTransitions = current.LeaderTransitions
+	} else {
+		record.LeaderTransitions = current.LeaderTransitions + 1
+	}
+	if err := e.config.Lock.Update(record); err != nil {
+		log.Printf("leader: failed to update %s: %s", e.config.Lock.Describe(), err.Error())
+		return false
+	}
+	e.observe(record, now)
+	e.leader = true
+	return true
+}
+
+func (e *Elector) observe(record Record, now time.Time) {
+	e.observed = record
+	e.observedTime = now
+}
+
+// leaseDuration returns the lease duration of the holder of record, falling
+// back to this replica's own.
+func (e *Elector) leaseDuration(record Record) time.Duration {
+	if record.LeaseDurationSeconds > 0 {
+		return time.Duration(record.LeaseDurationSeconds) * time.Second
+	}
+	return e.config.LeaseDuration
+}
+
+func recordEqual(a, b Record) bool {
+	return a.HolderIdentity == b.HolderIdentity &&
+		a.LeaseDurationSeconds == b.LeaseDurationSeconds &&
+		a.AcquireTime.Equal(b.AcquireTime) &&
+		a.RenewTime.Equal(b.RenewTime) &&
+		a.LeaderTransitions == b.LeaderTransitions
+}
@@ -0,0 +1,146 @@
+package leader
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// memoryLock is a Lock that fails updates of a record changed since it was
+// last read, like a config map with a stale resource version.
+type memoryLock struct {
+	record  *Record
+	version int
+	read    map[string]int
+}
+
+func (l *memoryLock) forIdentity(identity string) Lock {
+	return &memoryLockClient{lock: l, identity: identity}
+}
+
+type memoryLockClient struct {
+	lock     *memoryLock
+	identity string
+}
+
+func (c *memoryLockClient) Get() (*Record, error) {
+	c.lock.read[c.identity] = c.lock.version
+	if c.lock.record == nil {
+		return nil, nil
+	}
+	record := *c.lock.record
+	return &record, nil
+}
+
+func (c *memoryLockClient) Create(record Record) error {
+	if c.lock.record != nil {
+		return errors.New("already exists")
+	}
+	c.lock.record = &record
+	c.lock.version++
+	return nil
+}
+
+func (c *memoryLockClient) Update(record Record) error {
+	if c.lock.read[c.identity] != c.lock.version {
+		return errors.New("conflict")
+	}
+	c.lock.record = &record
+	c.lock.version++
+	return nil
+}
+
+func (c *memoryLockClient) Describe() string {
+	return "memory"
+}
+
+func Test_tryAcquireOrRenew(t *testing.T) {
+	now := time.Date(2017, 1, 20, 1, 2, 3, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	lock := &memoryLock{read: map[string]int{}}
+	newElector := func(identity string) *Elector {
+		e, err := NewElector(Config{
+			Lock:          lock.forIdentity(identity),
+			Identity:      identity,
+			LeaseDuration: 15 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   2 * time.Second,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return e
+	}
+	a, b := newElector("a"), newElector("b")
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		elector *Elector
+		release bool
+		want    bool
+		holder  string
+	}{
+		{name: "a creates the lock", elector: a, want: true, holder: "a"},
+		{name: "b waits for the lease", elector: b, want: false, holder: "a"},
+		{name: "a renews", advance: 2 * time.Second, elector: a, want: true, holder: "a"},
+		{name: "b sees a renewed record", advance: 2 * time.Second, elector: b, want: false, holder: "a"},
+		{name: "b waits a full lease from the last change", advance: 14 * time.Second, elector: b, want: false, holder: "a"},
+		{name: "b takes over the expired lease", advance: 2 * time.Second, elector: b, want: true, holder: "b"},
+		{name: "a is no longer leader", elector: a, want: false, holder: "b"},
+		{name: "b releases", elector: b, release: true, want: false, holder: ""},
+		{name: "a takes over the released lock", elector: a, want: true, holder: "a"},
+	}
+	for _, step := range steps {
+		now = now.Add(step.advance)
+		if step.release {
+			if err := step.elector.Release(); err != nil {
+				t.Fatalf("%s: Release() error = %v", step.name, err)
+			}
+		} else if got := step.elector.tryAcquireOrRenew(); got != step.want {
+			t.Fatalf("%s: tryAcquireOrRenew() = %t, want %t", step.name, got, step.want)
+		}
+		if step.elector.IsLeader() != step.want {
+			t.Fatalf("%s: IsLeader() = %t, want %t", step.name, step.elector.IsLeader(), step.want)
+		}
+		if lock.record.HolderIdentity != step.holder {
+			t.Fatalf("%s: holder = %q, want %q", step.name, lock.record.HolderIdentity, step.holder)
+		}
+	}
+	if lock.record.LeaderTransitions != 2 {
+		t.Errorf("LeaderTransitions = %d, want 2", lock.record.LeaderTransitions)
+	}
+}
+
+func Test_NewElector(t *testing.T) {
+	lock := &memoryLock{read: map[string]int{}}
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			config: Config{Lock: lock.forIdentity("a"), Identity: "a", LeaseDuration: 15 * time.Second, RenewDeadline: 10 * time.Second, RetryPeriod: 2 * time.Second},
+		},
+		{
+			name:    "renew deadline longer than lease",
+			config:  Config{Lock: lock.forIdentity("a"), Identity: "a", LeaseDuration: 10 * time.Second, RenewDeadline: 15 * time.Second, RetryPeriod: 2 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "no identity",
+			config:  Config{Lock: lock.forIdentity("a"), LeaseDuration: 15 * time.Second, RenewDeadline: 10 * time.Second, RetryPeriod: 2 * time.Second},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewElector(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("NewElector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
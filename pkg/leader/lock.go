@@ -0,0 +1,110 @@
+package leader
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/errors"
+	"k8s.io/client-go/pkg/api/unversioned"
+	"k8s.io/client-go/pkg/api/v1"
+)
+
+// RecordAnnotationKey is the annotation the leader record is stored in.
+const RecordAnnotationKey = "control-plane.alpha.kubernetes.io/leader"
+
+// Record is the state of the leader lock.
+type Record struct {
+	HolderIdentity       string           `json:"holderIdentity"`
+	LeaseDurationSeconds int              `json:"leaseDurationSeconds"`
+	AcquireTime          unversioned.Time `json:"acquireTime"`
+	RenewTime            unversioned.Time `json:"renewTime"`
+	LeaderTransitions    int              `json:"leaderTransitions"`
+}
+
+// Lock stores the leader record. Update must fail if the record changed
+// since it was last read with Get.
+type Lock interface {
+	// Get returns the current record, or nil if the lock doesn't exist.
+	Get() (*Record, error)
+	Create(record Record) error
+	Update(record Record) error
+	// Describe returns a name for the lock in logs.
+	Describe() string
+}
+
+// ConfigMapLock stores the leader record in an annotation of a config map.
+type ConfigMapLock struct {
+	Client    *kubernetes.Clientset
+	Namespace string
+	Name      string
+
+	configMap *v1.ConfigMap
+}
+
+func (l *ConfigMapLock) Get() (*Record, error) {
+	configMap, err := l.Client.Core().ConfigMaps(l.Namespace).Get(l.Name)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	l.configMap = configMap
+
+	record := &Record{}
+	raw, ok := configMap.Annotations[RecordAnnotationKey]
+	if !ok {
+		return record, nil
+	}
+	if err := json.Unmarshal([]byte(raw), record); err != nil {
+		return nil, fmt.Errorf("failed to parse leader record of %s: %s", l.Describe(), err.Error())
+	}
+	return record, nil
+}
+
+func (l *ConfigMapLock) Create(record Record) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	l.configMap, err = l.Client.Core().ConfigMaps(l.Namespace).Create(&v1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      l.Name,
+			Namespace: l.Namespace,
+			Annotations: map[string]string{
+				RecordAnnotationKey: string(raw),
+			},
+		},
+	})
+	return err
+}
+
+func (l *ConfigMapLock) Update(record Record) error {
+	if l.configMap == nil {
+		return fmt.Errorf("%s must be read before it is updated", l.Describe())
+	}
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	configMap := *l.configMap
+	configMap.Annotations = map[string]string{}
+	for k, v := range l.configMap.Annotations {
+		configMap.Annotations[k] = v
+	}
+	configMap.Annotations[RecordAnnotationKey] = string(raw)
+
+	updated, err := l.Client.Core().ConfigMaps(l.Namespace).Update(&configMap)
+	if err != nil {
+		return err
+	}
+	l.configMap = updated
+	return nil
+}
+
+func (l *ConfigMapLock) Describe() string {
+	return "configmap " + l.Namespace + "/" + l.Name
+}
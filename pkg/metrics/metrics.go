@@ -0,0 +1,327 @@
+// Package metrics serves metrics in the prometheus text exposition format.
+//
+// It implements the few metric types the controller needs: counters and
+// histograms with labels, and gauges computed when scraped.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultRegistry is the registry metrics of the controller are registered
+// with.
+var DefaultRegistry = NewRegistry()
+
+// DefaultBuckets are histogram buckets suited to request latencies in
+// seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Sample is a value of a metric with its label values.
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+type metric interface {
+	name() string
+	write(buf *bytes.Buffer)
+}
+
+// Registry holds metrics to serve.
+type Registry struct {
+	lock    sync.Mutex
+	metrics map[string]metric
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{metrics: map[string]metric{}}
+}
+
+func (r *Registry) register(m metric) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, exists := r.metrics[m.name()]; exists {
+		panic(fmt.Sprintf("metrics: %s is already registered", m.name()))
+	}
+	r.metrics[m.name()] = m
+}
+
+// Write writes all metrics in the text exposition format, sorted by name.
+func (r *Registry) Write(buf *bytes.Buffer) {
+	r.lock.Lock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	metrics := make([]metric, 0, len(names))
+	sort.Strings(names)
+	for _, name := range names {
+		metrics = append(metrics, r.metrics[name])
+	}
+	r.lock.Unlock()
+
+	for _, m := range metrics {
+		m.write(buf)
+	}
+}
+
+// ServeHTTP serves all metrics.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	buf := &bytes.Buffer{}
+	r.Write(buf)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
+
+// desc is the name, help and label names of a metric.
+type desc struct {
+	metricName string
+	help       string
+	typ        string
+	labels     []string
+}
+
+func (d *desc) name() string {
+	return d.metricName
+}
+
+func (d *desc) writeHeader(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", d.metricName, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(d.help))
+	fmt.Fprintf(buf, "# TYPE %s %s\n", d.metricName, d.typ)
+}
+
+func (d *desc) checkLabels(values []string) {
+	if len(values) != len(d.labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", d.metricName, len(d.labels), len(values)))
+	}
+}
+
+// CounterVec is a counter partitioned by labels.
+type CounterVec struct {
+	desc
+
+	lock   sync.Mutex
+	values map[string]*Sample
+}
+
+// NewCounterVec registers a counter with r.
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		desc:   desc{metricName: name, help: help, typ: "counter", labels: labels},
+		values: map[string]*Sample{},
+	}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the counter with the label values.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v to the counter with the label values.
+func (c *CounterVec) Add(v float64, labelValues ...string) {
+	c.checkLabels(labelValues)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := seriesKey(labelValues)
+	sample, ok := c.values[key]
+	if !ok {
+		sample = &Sample{LabelValues: append([]string(nil), labelValues...)}
+		c.values[key] = sample
+	}
+	sample.Value += v
+}
+
+// Value returns the counter with the label values.
+func (c *CounterVec) Value(labelValues ...string) float64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if sample, ok := c.values[seriesKey(labelValues)]; ok {
+		return sample.Value
+	}
+	return 0
+}
+
+func (c *CounterVec) write(buf *bytes.Buffer) {
+	c.lock.Lock()
+	samples := make([]Sample, 0, len(c.values))
+	for _, sample := range c.values {
+		samples = append(samples, *sample)
+	}
+	c.lock.Unlock()
+
+	c.writeHeader(buf)
+	for _, sample := range sortSamples(samples) {
+		writeSample(buf, c.metricName, c.labels, sample.LabelValues, sample.Value)
+	}
+}
+
+// HistogramVec is a histogram partitioned by labels.
+type HistogramVec struct {
+	desc
+	buckets []float64
+
+	lock   sync.Mutex
+	values map[string]*histogram
+}
+
+type histogram struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// NewHistogramVec registers a histogram with r. buckets are the upper bounds
+// of the buckets, in increasing order.
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{
+		desc:    desc{metricName: name, help: help, typ: "histogram", labels: labels},
+		buckets: buckets,
+		values:  map[string]*histogram{},
+	}
+	r.register(h)
+	return h
+}
+
+// Observe adds v to the histogram with the label values.
+func (h *HistogramVec) Observe(v float64, labelValues ...string) {
+	h.checkLabels(labelValues)
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	key := seriesKey(labelValues)
+	hist, ok := h.values[key]
+	if !ok {
+		hist = &histogram{
+			labelValues: append([]string(nil), labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.values[key] = hist
+	}
+	for i, bound := range h.buckets {
+		if v <= bound {
+			hist.counts[i]++
+		}
+	}
+	hist.count++
+	hist.sum += v
+}
+
+func (h *HistogramVec) write(buf *bytes.Buffer) {
+	h.lock.Lock()
+	hists := make([]histogram, 0, len(h.values))
+	for _, hist := range h.values {
+		copied := *hist
+		copied.counts = append([]uint64(nil), hist.counts...)
+		hists = append(hists, copied)
+	}
+	h.lock.Unlock()
+	sort.Slice(hists, func(i, j int) bool {
+		return seriesKey(hists[i].labelValues) < seriesKey(hists[j].labelValues)
+	})
+
+	h.writeHeader(buf)
+	labels := append(append([]string(nil), h.labels...), "le")
+	for _, hist := range hists {
+		for i, bound := range h.buckets {
+			writeSample(buf, h.metricName+"_bucket", labels, append(append([]string(nil), hist.labelValues...), formatFloat(bound)), float64(hist.counts[i]))
+		}
+		writeSample(buf, h.metricName+"_bucket", labels, append(append([]string(nil), hist.labelValues...), "+Inf"), float64(hist.count))
+		writeSample(buf, h.metricName+"_sum", h.labels, hist.labelValues, hist.sum)
+		writeSample(buf, h.metricName+"_count", h.labels, hist.labelValues, float64(hist.count))
+	}
+}
+
+// GaugeFunc is a gauge computed when scraped.
+type GaugeFunc struct {
+	desc
+	collect func() []Sample
+}
+
+// NewGaugeFunc registers a gauge without labels with r, with the value
+// returned by value.
+func (r *Registry) NewGaugeFunc(name, help string, value func() float64) *GaugeFunc {
+	return r.NewGaugeVecFunc(name, help, func() []Sample {
+		return []Sample{{Value: value()}}
+	})
+}
+
+// NewGaugeVecFunc registers a gauge partitioned by labels with r, with the
+// samples returned by collect.
+func (r *Registry) NewGaugeVecFunc(name, help string, collect func() []Sample, labels ...string) *GaugeFunc {
+	g := &GaugeFunc{
+		desc:    desc{metricName: name, help: help, typ: "gauge", labels: labels},
+		collect: collect,
+	}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeFunc) write(buf *bytes.Buffer) {
+	g.writeHeader(buf)
+	for _, sample := range sortSamples(g.collect()) {
+		if len(sample.LabelValues) != len(g.labels) {
+			continue
+		}
+		writeSample(buf, g.metricName, g.labels, sample.LabelValues, sample.Value)
+	}
+}
+
+func writeSample(buf *bytes.Buffer, name string, labels, values []string, value float64) {
+	buf.WriteString(name)
+	if len(labels) > 0 {
+		buf.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			fmt.Fprintf(buf, "%s=\"%s\"", label, escapeLabelValue(values[i]))
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(' ')
+	buf.WriteString(formatFloat(value))
+	buf.WriteByte('\n')
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func escapeLabelValue(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func seriesKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+func sortSamples(samples []Sample) []Sample {
+	sort.Slice(samples, func(i, j int) bool {
+		return seriesKey(samples[i].LabelValues) < seriesKey(samples[j].LabelValues)
+	})
+	return samples
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_Registry_Write(t *testing.T) {
+	tests := []struct {
+		name     string
+		register func(r *Registry)
+		want     string
+	}{
+		{
+			name: "counter",
+			register: func(r *Registry) {
+				c := r.NewCounterVec("requests_total", "Requests.", "operation", "result")
+				c.Inc("read", "success")
+				c.Inc("read", "success")
+				c.Add(3, "write", "error")
+			},
+			want: `# HELP requests_total Requests.
+# TYPE requests_total counter
+requests_total{operation="read",result="success"} 2
+requests_total{operation="write",result="error"} 3
+`,
+		},
+		{
+			name: "histogram",
+			register: func(r *Registry) {
+				h := r.NewHistogramVec("duration_seconds", "Durations.", []float64{0.1, 1}, "operation")
+				h.Observe(0.05, "read")
+				h.Observe(0.5, "read")
+				h.Observe(2, "read")
+			},
+			want: `# HELP duration_seconds Durations.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{operation="read",le="0.1"} 1
+duration_seconds_bucket{operation="read",le="1"} 2
+duration_seconds_bucket{operation="read",le="+Inf"} 3
+duration_seconds_sum{operation="read"} 2.55
+duration_seconds_count{operation="read"} 3
+`,
+		},
+		{
+			name: "gauges sorted by name and escaped",
+			register: func(r *Registry) {
+				r.NewGaugeVecFunc("lease_seconds", "Lease.", func() []Sample {
+					return []Sample{
+						{LabelValues: []string{"b", `quote"d`}, Value: 10},
+						{LabelValues: []string{"a", "app"}, Value: -5},
+					}
+				}, "namespace", "claim")
+				r.NewGaugeFunc("depth", "Depth.", func() float64 { return 4 })
+			},
+			want: `# HELP depth Depth.
+# TYPE depth gauge
+depth 4
+# HELP lease_seconds Lease.
+# TYPE lease_seconds gauge
+lease_seconds{namespace="a",claim="app"} -5
+lease_seconds{namespace="b",claim="quote\"d"} 10
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry()
+			tt.register(r)
+			buf := &bytes.Buffer{}
+			r.Write(buf)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Write() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
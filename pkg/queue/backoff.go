@@ -0,0 +1,62 @@
+package queue
+
+import (
+	"sync"
+	"time"
+)
+
+// Backoff tracks failures per key, doubling the delay for each failure from
+// Base up to Max.
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+
+	lock     sync.Mutex
+	failures map[string]int
+}
+
+// NewBackoff returns a backoff starting at base and capped at max.
+func NewBackoff(base, max time.Duration) *Backoff {
+	return &Backoff{
+		Base:     base,
+		Max:      max,
+		failures: map[string]int{},
+	}
+}
+
+// Next records a failure of key and returns the delay before it is retried.
+func (b *Backoff) Next(key string) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	exp := b.failures[key]
+	b.failures[key] = exp + 1
+
+	delay := b.Base
+	for i := 0; i < exp; i++ {
+		delay *= 2
+		if delay >= b.Max {
+			return b.Max
+		}
+	}
+	if delay > b.Max {
+		return b.Max
+	}
+	return delay
+}
+
+// Failures returns the number of failures of key since it was last reset.
+func (b *Backoff) Failures(key string) int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.failures[key]
+}
+
+// Reset forgets the failures of key.
+func (b *Backoff) Reset(key string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.failures, key)
+}
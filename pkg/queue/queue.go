@@ -0,0 +1,171 @@
+// Package queue implements a rate limited work queue of keys.
+//
+// A key is held at most once while waiting, and is never handed out again
+// until the worker processing it calls Done; a key added while it is being
+// processed is queued again once it is done.
+package queue
+
+import (
+	"sync"
+	"time"
+)
+
+// Queue is a rate limited work queue of keys.
+type Queue struct {
+	cond *sync.Cond
+
+	order      []string
+	dirty      map[string]struct{}
+	processing map[string]struct{}
+	waiting    map[string]*delayed
+	shutdown   bool
+
+	backoff *Backoff
+}
+
+type delayed struct {
+	timer *time.Timer
+	ready time.Time
+}
+
+// New returns a queue using backoff for rate limited adds.
+func New(backoff *Backoff) *Queue {
+	return &Queue{
+		cond:       sync.NewCond(&sync.Mutex{}),
+		dirty:      map[string]struct{}{},
+		processing: map[string]struct{}{},
+		waiting:    map[string]*delayed{},
+		backoff:    backoff,
+	}
+}
+
+// Add queues key unless it is already queued.
+func (q *Queue) Add(key string) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.add(key)
+}
+
+func (q *Queue) add(key string) {
+	if q.shutdown {
+		return
+	}
+	if _, ok := q.dirty[key]; ok {
+		return
+	}
+	q.dirty[key] = struct{}{}
+	if _, ok := q.processing[key]; ok {
+		return
+	}
+	q.order = append(q.order, key)
+	q.cond.Signal()
+}
+
+// AddAfter queues key after delay. If key is already waiting to be queued,
+// the earlier of the two times is kept.
+func (q *Queue) AddAfter(key string, delay time.Duration) {
+	if delay <= 0 {
+		q.Add(key)
+		return
+	}
+
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.shutdown {
+		return
+	}
+	ready := time.Now().Add(delay)
+	if existing, ok := q.waiting[key]; ok {
+		if !existing.ready.After(ready) {
+			return
+		}
+		existing.timer.Stop()
+	}
+
+	d := &delayed{ready: ready}
+	d.timer = time.AfterFunc(delay, func() {
+		q.cond.L.Lock()
+		defer q.cond.L.Unlock()
+
+		if q.waiting[key] == d {
+			delete(q.waiting, key)
+		}
+		q.add(key)
+	})
+	q.waiting[key] = d
+}
+
+// AddRateLimited queues key after its next backoff.
+func (q *Queue) AddRateLimited(key string) {
+	q.AddAfter(key, q.backoff.Next(key))
+}
+
+// Forget resets the backoff of key, once it was processed successfully or
+// is given up on.
+func (q *Queue) Forget(key string) {
+	q.backoff.Reset(key)
+}
+
+// NumRequeues returns the number of rate limited adds of key since it was
+// last forgotten.
+func (q *Queue) NumRequeues(key string) int {
+	return q.backoff.Failures(key)
+}
+
+// Get blocks until a key is queued and returns it. The caller must call Done
+// with the key once it is processed. shutdown is true once the queue is shut
+// down.
+func (q *Queue) Get() (key string, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for len(q.order) == 0 && !q.shutdown {
+		q.cond.Wait()
+	}
+	if len(q.order) == 0 {
+		return "", true
+	}
+
+	key, q.order = q.order[0], q.order[1:]
+	delete(q.dirty, key)
+	q.processing[key] = struct{}{}
+	return key, false
+}
+
+// Done marks key as processed, queueing it again if it was added while it
+// was being processed.
+func (q *Queue) Done(key string) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	delete(q.processing, key)
+	if _, ok := q.dirty[key]; ok {
+		q.order = append(q.order, key)
+		q.cond.Signal()
+	}
+}
+
+// Len returns the number of queued keys.
+func (q *Queue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return len(q.order)
+}
+
+// ShutDown stops the queue. Keys still queued are dropped and Get returns
+// shutdown to all workers.
+func (q *Queue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	q.shutdown = true
+	q.order = nil
+	for key, d := range q.waiting {
+		d.timer.Stop()
+		delete(q.waiting, key)
+	}
+	q.cond.Broadcast()
+}
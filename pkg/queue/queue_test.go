@@ -0,0 +1,102 @@
+package queue
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_Backoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     time.Duration
+		max      time.Duration
+		failures int
+		want     []time.Duration
+	}{
+		{
+			name:     "doubles per failure",
+			base:     time.Second,
+			max:      time.Minute,
+			failures: 4,
+			want:     []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+		{
+			name:     "capped at max",
+			base:     time.Second,
+			max:      5 * time.Second,
+			failures: 5,
+			want:     []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBackoff(tt.base, tt.max)
+			var got []time.Duration
+			for i := 0; i < tt.failures; i++ {
+				got = append(got, b.Next("key"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+			if b.Failures("key") != tt.failures {
+				t.Errorf("Failures() = %d, want %d", b.Failures("key"), tt.failures)
+			}
+			b.Reset("key")
+			if next := b.Next("key"); next != tt.base {
+				t.Errorf("Next() after Reset() = %s, want %s", next, tt.base)
+			}
+		})
+	}
+}
+
+func Test_Queue(t *testing.T) {
+	q := New(NewBackoff(time.Millisecond, time.Second))
+
+	q.Add("a")
+	q.Add("b")
+	q.Add("a")
+	if q.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2 after adding a duplicate key", q.Len())
+	}
+
+	key, _ := q.Get()
+	if key != "a" {
+		t.Fatalf("Get() = %s, want a", key)
+	}
+
+	// a is being processed, so adding it again must not hand it out until
+	// it is done.
+	q.Add("a")
+	if key, _ := q.Get(); key != "b" {
+		t.Fatalf("Get() = %s, want b", key)
+	}
+	if q.Len() != 0 {
+		t.Fatalf("Len() = %d, want 0 while a is processing", q.Len())
+	}
+	q.Done("b")
+	q.Done("a")
+	if key, _ := q.Get(); key != "a" {
+		t.Fatalf("Get() = %s, want a after done", key)
+	}
+	q.Done("a")
+
+	q.AddRateLimited("c")
+	if q.NumRequeues("c") != 1 {
+		t.Fatalf("NumRequeues() = %d, want 1", q.NumRequeues("c"))
+	}
+	if key, _ := q.Get(); key != "c" {
+		t.Fatalf("Get() = %s, want c after backoff", key)
+	}
+	q.Done("c")
+	q.Forget("c")
+	if q.NumRequeues("c") != 0 {
+		t.Fatalf("NumRequeues() = %d, want 0 after Forget()", q.NumRequeues("c"))
+	}
+
+	q.AddAfter("d", time.Hour)
+	q.ShutDown()
+	if _, shutdown := q.Get(); !shutdown {
+		t.Fatalf("Get() after ShutDown() did not return shutdown")
+	}
+}
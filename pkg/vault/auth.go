@@ -0,0 +1,220 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+type loginFunc func(client *vaultapi.Client) (*vaultapi.Secret, error)
+
+// tokenCacheIdleTimeout is how long a login may go unused before it is
+// evicted, for example because its service account or namespace was deleted.
+// Evicted tokens are no longer renewed and expire.
+const tokenCacheIdleTimeout = 2 * time.Hour
+
+// tokenCache holds vault clients for tokens obtained by logging in on behalf
+// of claims, keyed by the identity used to login.
+type tokenCache struct {
+	vconfig *vaultapi.Config
+
+	// mu guards entries. Each entry has its own lock, held while it renews
+	// or logs in, so a slow login only blocks claims with the same identity.
+	mu      sync.Mutex
+	entries map[string]*tokenEntry
+}
+
+type tokenEntry struct {
+	mu         sync.Mutex
+	client     *vaultapi.Client
+	ttl        time.Duration
+	expiration time.Time
+	renewable  bool
+
+	// lastUsed is guarded by the cache lock.
+	lastUsed time.Time
+}
+
+func newTokenCache(vconfig *vaultapi.Config) *tokenCache {
+	return &tokenCache{
+		vconfig: vconfig,
+		entries: map[string]*tokenEntry{},
+	}
+}
+
+// valid returns true if the token is not within the last third of its ttl.
+// Tokens without a ttl never expire.
+func (entry *tokenEntry) valid() bool {
+	if entry.ttl == 0 {
+		return true
+	}
+	return entry.expiration.Sub(timeNow()) > entry.ttl/3
+}
+
+func (entry *tokenEntry) update(auth *vaultapi.SecretAuth) {
+	entry.ttl = time.Duration(auth.LeaseDuration) * time.Second
+	entry.expiration = timeNow().Add(entry.ttl)
+	entry.renewable = auth.Renewable
+}
+
+// entry returns the entry of key, creating it if needed, and evicts the
+// entries that were idle for too long.
+func (cache *tokenCache) entry(key string) *tokenEntry {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	now := timeNow()
+	for k, entry := range cache.entries {
+		if k != key && now.Sub(entry.lastUsed) > tokenCacheIdleTimeout {
+			log.Printf("vault-controller: %s: evicting token, unused for %s", k, tokenCacheIdleTimeout)
+			delete(cache.entries, k)
+		}
+	}
+
+	entry, ok := cache.entries[key]
+	if !ok {
+		entry = &tokenEntry{}
+		cache.entries[key] = entry
+	}
+	entry.lastUsed = now
+	return entry
+}
+
+// client returns a vault client authenticated as key, renewing the cached
+// token if possible and logging in again otherwise.
+func (cache *tokenCache) client(key string, login loginFunc) (*vaultapi.Client, error) {
+	entry := cache.entry(key)
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.client != nil && entry.valid() {
+		return entry.client, nil
+	}
+
+	if entry.client != nil && entry.renewable && entry.expiration.After(timeNow()) {
+		start := time.Now()
+		secret, err := entry.client.Auth().Token().RenewSelf(0)
+		observeVault("renew_token", start, err)
+		if err == nil && secret != nil && secret.Auth != nil {
+			entry.update(secret.Auth)
+			if entry.valid() {
+				log.Printf("vault-controller: %s: token renewed for %s", key, entry.ttl)
+				return entry.client, nil
+			}
+		} else if err != nil {
+			log.Printf("vault-controller: %s: failed to renew token - %s", key, err.Error())
+		}
+	}
+	entry.client = nil
+
+	client, err := newClient(cache.vconfig)
+	if err != nil {
+		return nil, err
+	}
+	client.ClearToken()
+
+	secret, err := login(client)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, errors.New("login response did not include a client token")
+	}
+
+	client.SetToken(secret.Auth.ClientToken)
+	entry.client = client
+	entry.update(secret.Auth)
+
+	log.Printf("vault-controller: %s: logged in, token valid for %s", key, entry.ttl)
+	return client, nil
+}
+
+// clientForClaim returns the vault client the claim should be fulfilled with.
+func (ctrl *controller) clientForClaim(claim *kube.SecretClaim) (*vaultapi.Client, error) {
+	switch {
+	case claim.Spec.ServiceAccountName != "" && claim.Spec.AppRoleSecretName != "":
+		return nil, errors.New("only one of serviceAccountName and appRoleSecretName may be set")
+	case claim.Spec.ServiceAccountName != "":
+		return ctrl.kubernetesClient(claim)
+	case claim.Spec.AppRoleSecretName != "":
+		return ctrl.appRoleClient(claim)
+	}
+	return ctrl.token.Client(), nil
+}
+
+func (ctrl *controller) kubernetesClient(claim *kube.SecretClaim) (*vaultapi.Client, error) {
+	role := claim.Spec.VaultRole
+	if role == "" {
+		role = claim.Namespace
+	}
+
+	key := fmt.Sprintf("kubernetes:%s/%s:%s", claim.Namespace, claim.Spec.ServiceAccountName, role)
+	return ctrl.tokens.client(key, func(client *vaultapi.Client) (*vaultapi.Secret, error) {
+		jwt, err := ctrl.serviceAccountToken(claim.Namespace, claim.Spec.ServiceAccountName)
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		secret, err := client.Logical().Write("auth/"+ctrl.kubernetesAuthPath+"/login", map[string]interface{}{
+			"role": role,
+			"jwt":  jwt,
+		})
+		observeVault("login", start, err)
+		return secret, err
+	})
+}
+
+func (ctrl *controller) appRoleClient(claim *kube.SecretClaim) (*vaultapi.Client, error) {
+	key := fmt.Sprintf("approle:%s/%s", claim.Namespace, claim.Spec.AppRoleSecretName)
+	return ctrl.tokens.client(key, func(client *vaultapi.Client) (*vaultapi.Secret, error) {
+		secret, err := ctrl.kclient.Core().Secrets(claim.Namespace).Get(claim.Spec.AppRoleSecretName)
+		if err != nil {
+			return nil, err
+		}
+
+		roleID := string(secret.Data[AppRoleRoleIDKey])
+		if roleID == "" {
+			return nil, fmt.Errorf("approle secret %s has no %s", claim.Spec.AppRoleSecretName, AppRoleRoleIDKey)
+		}
+		data := map[string]interface{}{
+			AppRoleRoleIDKey: roleID,
+		}
+		if secretID := string(secret.Data[AppRoleSecretIDKey]); secretID != "" {
+			data[AppRoleSecretIDKey] = secretID
+		}
+
+		start := time.Now()
+		login, err := client.Logical().Write("auth/"+ctrl.appRoleAuthPath+"/login", data)
+		observeVault("login", start, err)
+		return login, err
+	})
+}
+
+// serviceAccountToken returns the jwt for the named service account.
+func (ctrl *controller) serviceAccountToken(namespace, name string) (string, error) {
+	sa, err := ctrl.kclient.Core().ServiceAccounts(namespace).Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	for _, ref := range sa.Secrets {
+		secret, err := ctrl.kclient.Core().Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			continue
+		}
+		if secret.Type != v1.SecretTypeServiceAccountToken {
+			continue
+		}
+		if token := secret.Data[v1.ServiceAccountTokenKey]; len(token) > 0 {
+			return string(token), nil
+		}
+	}
+	return "", fmt.Errorf("no token found for service account %s/%s", namespace, name)
+}
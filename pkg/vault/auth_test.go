@@ -0,0 +1,292 @@
+package vault
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"k8s.io/client-go/pkg/api"
+)
+
+func loginResponse(token string, ttl int) string {
+	return fmt.Sprintf(`{"auth":{"client_token":%q,"lease_duration":%d,"renewable":true}}`, token, ttl)
+}
+
+// recordLogin responds to key with a login for token, recording the request
+// body in body.
+func recordLogin(vault *fakeVault, key, token string, body *map[string]interface{}) {
+	vault.handle(key, func(w http.ResponseWriter, req *http.Request) {
+		*body = nil
+		json.NewDecoder(req.Body).Decode(body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, loginResponse(token, 3600))
+	})
+}
+
+func secretJSON(name, typ string, data map[string]string) string {
+	encoded := map[string]string{}
+	for k, v := range data {
+		encoded[k] = base64.StdEncoding.EncodeToString([]byte(v))
+	}
+	raw, _ := json.Marshal(map[string]interface{}{
+		"kind":       "Secret",
+		"apiVersion": "v1",
+		"metadata":   map[string]string{"name": name, "namespace": "example"},
+		"type":       typ,
+		"data":       encoded,
+	})
+	return string(raw)
+}
+
+func Test_kubernetesClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		vaultRole  string
+		tokenType  string
+		wantRole   string
+		wantErr    bool
+		wantLogins int
+	}{
+		{name: "role defaults to namespace", tokenType: "kubernetes.io/service-account-token", wantRole: "example", wantLogins: 1},
+		{name: "vault role", vaultRole: "reader", tokenType: "kubernetes.io/service-account-token", wantRole: "reader", wantLogins: 1},
+		{name: "no service account token", tokenType: "Opaque", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := newFakeVault(t)
+			defer vault.Close()
+			ctrl := newTestController(t, vault)
+			ctrl.kubernetesAuthPath = "kubernetes"
+
+			vault.respond("GET /api/v1/namespaces/example/serviceaccounts/app", http.StatusOK,
+				`{"kind":"ServiceAccount","apiVersion":"v1","metadata":{"name":"app","namespace":"example"},"secrets":[{"name":"app-token"}]}`)
+			vault.respond("GET /api/v1/namespaces/example/secrets/app-token", http.StatusOK,
+				secretJSON("app-token", tt.tokenType, map[string]string{"token": "service-account-jwt"}))
+			var body map[string]interface{}
+			recordLogin(vault, "PUT /v1/auth/kubernetes/login", "app-token", &body)
+
+			claim := &kube.SecretClaim{
+				ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example"},
+				Spec:       kube.SecretSpec{ServiceAccountName: "app", VaultRole: tt.vaultRole},
+			}
+			client, err := ctrl.clientForClaim(claim)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("clientForClaim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(vault.requests("PUT /v1/auth/kubernetes/login")); got != tt.wantLogins {
+				t.Errorf("clientForClaim() logged in %d times, want %d", got, tt.wantLogins)
+			}
+			if err != nil {
+				return
+			}
+
+			want := map[string]interface{}{"role": tt.wantRole, "jwt": "service-account-jwt"}
+			if !reflect.DeepEqual(body, want) {
+				t.Errorf("login request = %v, want %v", body, want)
+			}
+			if client.Token() != "app-token" {
+				t.Errorf("clientForClaim() token = %q, want %q", client.Token(), "app-token")
+			}
+			if _, err := ctrl.clientForClaim(claim); err != nil {
+				t.Fatal(err)
+			}
+			if got := len(vault.requests("PUT /v1/auth/kubernetes/login")); got != 1 {
+				t.Errorf("cached clientForClaim() logged in %d times, want 1", got)
+			}
+		})
+	}
+}
+
+func Test_appRoleClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "role and secret id",
+			data: map[string]string{AppRoleRoleIDKey: "role", AppRoleSecretIDKey: "secret"},
+			want: map[string]interface{}{AppRoleRoleIDKey: "role", AppRoleSecretIDKey: "secret"},
+		},
+		{
+			name: "role id only",
+			data: map[string]string{AppRoleRoleIDKey: "role"},
+			want: map[string]interface{}{AppRoleRoleIDKey: "role"},
+		},
+		{
+			name:    "no role id",
+			data:    map[string]string{AppRoleSecretIDKey: "secret"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := newFakeVault(t)
+			defer vault.Close()
+			ctrl := newTestController(t, vault)
+			ctrl.appRoleAuthPath = "approle"
+
+			vault.respond("GET /api/v1/namespaces/example/secrets/approle", http.StatusOK, secretJSON("approle", "Opaque", tt.data))
+			var body map[string]interface{}
+			recordLogin(vault, "PUT /v1/auth/approle/login", "approle-token", &body)
+
+			claim := &kube.SecretClaim{
+				ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example"},
+				Spec:       kube.SecretSpec{AppRoleSecretName: "approle"},
+			}
+			client, err := ctrl.clientForClaim(claim)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("clientForClaim() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(body, tt.want) {
+				t.Errorf("login request = %v, want %v", body, tt.want)
+			}
+			if client.Token() != "approle-token" {
+				t.Errorf("clientForClaim() token = %q, want %q", client.Token(), "approle-token")
+			}
+		})
+	}
+}
+
+func Test_tokenCache_client(t *testing.T) {
+	now := timeNow()
+	defer func(previous func() time.Time) { timeNow = previous }(timeNow)
+	timeNow = func() time.Time { return now }
+
+	vault := newFakeVault(t)
+	defer vault.Close()
+	cache := newTokenCache(vault.config())
+
+	logins := 0
+	var loginErr error
+	login := func(client *vaultapi.Client) (*vaultapi.Secret, error) {
+		logins++
+		if loginErr != nil {
+			return nil, loginErr
+		}
+		return &vaultapi.Secret{Auth: &vaultapi.SecretAuth{
+			ClientToken:   fmt.Sprintf("token-%d", logins),
+			LeaseDuration: 3600,
+			Renewable:     true,
+		}}, nil
+	}
+
+	steps := []struct {
+		name        string
+		after       time.Duration
+		renewStatus int
+		loginErr    error
+		wantToken   string
+		wantErr     bool
+		wantLogins  int
+		wantRenews  int
+	}{
+		{name: "logs in", wantToken: "token-1", wantLogins: 1},
+		{name: "cached", after: 30 * time.Minute, wantToken: "token-1", wantLogins: 1},
+		{name: "renewed in the last third of its ttl", after: 15 * time.Minute, renewStatus: http.StatusOK, wantToken: "token-1", wantLogins: 1, wantRenews: 1},
+		{name: "logs in again when renewal fails", after: 45 * time.Minute, renewStatus: http.StatusForbidden, wantToken: "token-2", wantLogins: 2, wantRenews: 2},
+		{name: "logs in again when expired", after: 2 * time.Hour, wantToken: "token-3", wantLogins: 3, wantRenews: 2},
+		{name: "failed login", after: 2 * time.Hour, loginErr: errors.New("permission denied"), wantErr: true, wantLogins: 4, wantRenews: 2},
+		{name: "failed login is not cached", loginErr: errors.New("permission denied"), wantErr: true, wantLogins: 5, wantRenews: 2},
+	}
+	for _, step := range steps {
+		now = now.Add(step.after)
+		if step.renewStatus == http.StatusOK {
+			vault.respond(renewSelf, http.StatusOK, loginResponse("token-1", 3600))
+		} else {
+			vault.respond(renewSelf, http.StatusForbidden, `{"errors":["permission denied"]}`)
+		}
+		loginErr = step.loginErr
+
+		client, err := cache.client("kubernetes:example/app:example", login)
+		if (err != nil) != step.wantErr {
+			t.Fatalf("%s: client() error = %v, wantErr %v", step.name, err, step.wantErr)
+		}
+		if err == nil && client.Token() != step.wantToken {
+			t.Errorf("%s: client() token = %q, want %q", step.name, client.Token(), step.wantToken)
+		}
+		if logins != step.wantLogins {
+			t.Errorf("%s: client() logged in %d times, want %d", step.name, logins, step.wantLogins)
+		}
+		if renews := len(vault.requests(renewSelf)); renews != step.wantRenews {
+			t.Errorf("%s: client() renewed %d times, want %d", step.name, renews, step.wantRenews)
+		}
+	}
+}
+
+func Test_tokenCache_lockPerKey(t *testing.T) {
+	vault := newFakeVault(t)
+	defer vault.Close()
+	cache := newTokenCache(vault.config())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	slow := func(client *vaultapi.Client) (*vaultapi.Secret, error) {
+		close(started)
+		<-release
+		return &vaultapi.Secret{Auth: &vaultapi.SecretAuth{ClientToken: "slow"}}, nil
+	}
+	fast := func(client *vaultapi.Client) (*vaultapi.Secret, error) {
+		return &vaultapi.Secret{Auth: &vaultapi.SecretAuth{ClientToken: "fast"}}, nil
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		cache.client("approle:example/slow", slow)
+	}()
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		cache.client("approle:example/fast", fast)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Error("client() blocked on the login of another key")
+	}
+	close(release)
+	wg.Wait()
+}
+
+func Test_tokenCache_evict(t *testing.T) {
+	now := timeNow()
+	defer func(previous func() time.Time) { timeNow = previous }(timeNow)
+	timeNow = func() time.Time { return now }
+
+	vault := newFakeVault(t)
+	defer vault.Close()
+	cache := newTokenCache(vault.config())
+	login := func(client *vaultapi.Client) (*vaultapi.Secret, error) {
+		return &vaultapi.Secret{Auth: &vaultapi.SecretAuth{ClientToken: "token"}}, nil
+	}
+
+	cache.client("kubernetes:deleted/app:deleted", login)
+	cache.client("kubernetes:example/app:example", login)
+	now = now.Add(tokenCacheIdleTimeout / 2)
+	cache.client("kubernetes:example/app:example", login)
+	now = now.Add(tokenCacheIdleTimeout/2 + time.Minute)
+	cache.client("kubernetes:example/app:example", login)
+
+	if _, ok := cache.entries["kubernetes:deleted/app:deleted"]; ok {
+		t.Error("client() kept an idle entry")
+	}
+	if _, ok := cache.entries["kubernetes:example/app:example"]; !ok {
+		t.Error("client() evicted an entry in use")
+	}
+}
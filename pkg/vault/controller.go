@@ -11,6 +11,7 @@ import (
 	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/roboll/kube-vault-controller/pkg/kube"
 	"k8s.io/client-go/kubernetes"
+	kerrors "k8s.io/client-go/pkg/api/errors"
 	v1 "k8s.io/client-go/pkg/api/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -22,31 +23,118 @@ const (
 	LeaseIDKey         = "vaultproject.io/lease-id"
 	LeaseExpirationKey = "vaultproject.io/lease-expiration"
 	RenewableKey       = "vaultproject.io/renewable"
+	KVVersionKey       = "vaultproject.io/kv-version"
 
 	PKICertificateKey = "certificate"
 	PKIPrivateKeyKey  = "private_key"
+
+	AppRoleRoleIDKey   = "role_id"
+	AppRoleSecretIDKey = "secret_id"
 )
 
+// Config configures the vault controller.
+type Config struct {
+	NamespacePrefix string
+	// PathPolicy restricts the paths claims may read, in place of
+	// NamespacePrefix.
+	PathPolicy *PathPolicy
+
+	// WritePaths are globs of the paths claims with data may write to, with
+	// the same syntax as path rules. Writes to other paths are denied.
+	WritePaths []string
+	// DisableWrites denies all writes, whatever the WritePaths.
+	DisableWrites bool
+
+	// Token is the controller token, used for claims without authentication
+	// of their own.
+	Token *Token
+
+	// KubernetesAuthPath is the mount path of the kubernetes auth method,
+	// used for claims with a service account.
+	KubernetesAuthPath string
+	// AppRoleAuthPath is the mount path of the approle auth method, used for
+	// claims with an approle secret.
+	AppRoleAuthPath string
+
+	// MaxRevokeFailures is the number of times revoking the lease of a
+	// deleted claim may fail before its secret is deleted without revoking
+	// it, leaving the lease to expire. Zero retries forever.
+	MaxRevokeFailures int
+}
+
 type controller struct {
-	vclient         *vaultapi.Client
-	kclient         *kubernetes.Clientset
-	namespacePrefix string
+	token   *Token
+	kclient *kubernetes.Clientset
+	claims  *rest.RESTClient
+	tokens  *tokenCache
+	mounts  *mountCache
+	events  *eventRecorder
+	issued  *issuedLeases
+
+	revokeFailures    *revokeFailures
+	maxRevokeFailures int
+
+	namespacePrefix    string
+	pathPolicy         *PathPolicy
+	writePolicy        *PathPolicy
+	disableWrites      bool
+	kubernetesAuthPath string
+	appRoleAuthPath    string
 }
 
-func NewController(vconfig *vaultapi.Config, kconfig *rest.Config, namespacePrefix string) (kube.SecretClaimManager, error) {
-	vclient, err := vaultapi.NewClient(vconfig)
+func NewController(vconfig *vaultapi.Config, kconfig *rest.Config, config *Config) (kube.SecretClaimManager, error) {
+	token := config.Token
+	if token == nil {
+		var err error
+		token, err = NewToken(vconfig, &TokenConfig{})
+		if err != nil {
+			return nil, err
+		}
+	}
+	kclient, err := kubernetes.NewForConfig(kconfig)
 	if err != nil {
 		return nil, err
 	}
-	kclient, err := kubernetes.NewForConfig(kconfig)
+	claims, err := kube.NewSecretClaimClient(kconfig)
 	if err != nil {
 		return nil, err
 	}
 
+	writePolicy := &PathPolicy{Default: EffectDeny}
+	for _, path := range config.WritePaths {
+		writePolicy.Rules = append(writePolicy.Rules, PathRule{Path: path, Effect: EffectAllow})
+	}
+	if err := writePolicy.validate(); err != nil {
+		return nil, fmt.Errorf("vault-controller: write paths: %s", err.Error())
+	}
+
+	kubernetesAuthPath := config.KubernetesAuthPath
+	if kubernetesAuthPath == "" {
+		kubernetesAuthPath = "kubernetes"
+	}
+	appRoleAuthPath := config.AppRoleAuthPath
+	if appRoleAuthPath == "" {
+		appRoleAuthPath = "approle"
+	}
+
 	return &controller{
-		vclient:         vclient,
-		kclient:         kclient,
-		namespacePrefix: namespacePrefix,
+		token:   token,
+		kclient: kclient,
+		claims:  claims,
+		tokens:  newTokenCache(vconfig),
+		mounts:  &mountCache{},
+		events:  newEventRecorder(kclient),
+		issued:  newIssuedLeases(),
+
+		revokeFailures:    newRevokeFailures(),
+		maxRevokeFailures: config.MaxRevokeFailures,
+
+		namespacePrefix:    config.NamespacePrefix,
+		pathPolicy:         config.PathPolicy,
+		writePolicy:        writePolicy,
+		disableWrites:      config.DisableWrites,
+		kubernetesAuthPath: kubernetesAuthPath,
+		appRoleAuthPath:    appRoleAuthPath,
 	}, nil
 }
 
@@ -64,72 +152,225 @@ func pathAllowed(path string, prefix string, namespace string) bool {
 	return false
 }
 
-func (ctrl *controller) CreateOrUpdateSecret(claim *kube.SecretClaim, force bool) error {
+// readAllowed returns an error if the path policy or namespace prefix don't
+// let the claim read path.
+func (ctrl *controller) readAllowed(key, path string, claim *kube.SecretClaim) error {
+	if ctrl.pathPolicy != nil {
+		if allowed, rule := ctrl.pathPolicy.allowed(path, claim.Namespace, claim.Name); !allowed {
+			decidedBy := "the default effect"
+			if rule != nil {
+				decidedBy = fmt.Sprintf("the rule %q", rule.Path)
+			}
+			return &claimError{
+				reason: ReasonPathDenied,
+				err:    fmt.Errorf("vault-controller: %q: can't create path %q because it is denied by %s of the path policy", key, path, decidedBy),
+			}
+		}
+	} else if ctrl.namespacePrefix != "" {
+		if !pathAllowed(path, ctrl.namespacePrefix, claim.Namespace) {
+			return &claimError{
+				reason: ReasonPathDenied,
+				err:    fmt.Errorf("vault-controller: %q: can't create path %q because it is under the namespacePrefix %q but not in its own namespace %q", key, path, ctrl.namespacePrefix, claim.Namespace),
+			}
+		}
+	}
+	return nil
+}
+
+// writeAllowed returns an error if the claim may not write its data to path.
+func (ctrl *controller) writeAllowed(key, path string, claim *kube.SecretClaim) error {
+	if ctrl.disableWrites {
+		return &claimError{
+			reason: ReasonWriteDenied,
+			err:    fmt.Errorf("vault-controller: %q: can't write to path %q because writes are disabled", key, claim.Spec.Path),
+		}
+	}
+	if allowed, _ := ctrl.writePolicy.allowed(path, claim.Namespace, claim.Name); !allowed {
+		return &claimError{
+			reason: ReasonWriteDenied,
+			err:    fmt.Errorf("vault-controller: %q: can't write to path %q because it is not in the write paths", key, claim.Spec.Path),
+		}
+	}
+	return nil
+}
+
+func (ctrl *controller) CreateOrUpdateSecret(claim *kube.SecretClaim, force bool) (time.Duration, error) {
 	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(claim)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	if ctrl.namespacePrefix != "" {
-		if !pathAllowed(claim.Spec.Path, ctrl.namespacePrefix, claim.Namespace) {
-			return fmt.Errorf("vault-controller: %q: can't create path %q because it is under the namespacePrefix %q but not in its own namespace %q", key, claim.Spec.Path, ctrl.namespacePrefix, claim.Namespace)
+	if claim.DeletionTimestamp != nil {
+		return 0, ctrl.finalize(key, claim)
+	}
+	claim, err = ctrl.ensureFinalizer(key, claim)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := ctrl.createOrUpdateSecret(key, claim, force)
+	ctrl.recordSync(claim, result, err)
+	ctrl.updateStatus(key, claim, result, err)
+	if err != nil {
+		return 0, err
+	}
+	return ctrl.timeUntilSync(key, claim, result.secret), nil
+}
+
+// kvVersionCheckInterval is how often unpinned KV version 2 secrets are
+// checked for new versions, as they have no lease to schedule a sync for.
+const kvVersionCheckInterval = 5 * time.Minute
+
+// timeUntilSync returns how long until the secret should be renewed or
+// rotated, or zero if it has no lease to schedule a sync for. KV version 2
+// secrets don't expire; unpinned ones are checked for new versions every
+// kvVersionCheckInterval.
+func (ctrl *controller) timeUntilSync(key string, claim *kube.SecretClaim, secret *v1.Secret) time.Duration {
+	if secret == nil {
+		return 0
+	}
+	if _, ok := secret.Annotations[KVVersionKey]; ok {
+		if claim.Spec.Version != 0 {
+			return 0
+		}
+		return kvVersionCheckInterval
+	}
+
+	updateTime, err := ctrl.timeUntilUpdate(key, claim, secret)
+	if err != nil {
+		return 0
+	}
+	if updateTime <= 0 {
+		log.Printf("vault-controller: %s: lease is shorter than the renew buffer, not scheduling a sync", key)
+		return 0
+	}
+	return updateTime
+}
+
+// createOrUpdateSecret syncs the secret for a claim. The result may be nil
+// on error, if the state of the secret is not known.
+func (ctrl *controller) createOrUpdateSecret(key string, claim *kube.SecretClaim, force bool) (*syncResult, error) {
+	path, err := canonicalPath(claim.Spec.Path)
+	if err != nil {
+		return nil, &claimError{
+			reason: ReasonInvalidPath,
+			err:    fmt.Errorf("vault-controller: %q: invalid path %q: %s", key, claim.Spec.Path, err.Error()),
+		}
+	}
+	if err := ctrl.readAllowed(key, path, claim); err != nil {
+		return nil, err
+	}
+
+	if len(claim.Spec.Data) > 0 || claim.Spec.PrivateKey != nil {
+		if err := ctrl.writeAllowed(key, path, claim); err != nil {
+			return nil, err
 		}
 	}
 
 	existing, err := ctrl.kclient.Core().Secrets(claim.Namespace).Get(claim.Name)
 	if err != nil {
 		log.Printf("vault-controller: %s: creating secret from path %s", key, claim.Spec.Path)
-		err := ctrl.createSecret(key, claim)
+		created, err := ctrl.createSecret(key, claim)
 		if err != nil {
-			log.Printf("vault-controller: %s: created secret from path %s", key, claim.Spec.Path)
+			return nil, err
 		}
-		return err
+		log.Printf("vault-controller: %s: created secret from path %s", key, claim.Spec.Path)
+		return &syncResult{reason: ReasonCreated, secret: created}, nil
 	}
 
-	shouldUpdate := force
-	if !shouldUpdate {
-		updateTime, err := ctrl.timeUntilUpdate(key, claim, existing)
+	if !ownedBy(existing, claim) {
+		if err := ctrl.adoptSecret(key, claim, existing); err != nil {
+			return nil, err
+		}
+		updated, err := ctrl.updateSecret(key, claim, nil)
 		if err != nil {
-			shouldUpdate = true
-			log.Printf("vault-controller: %s: %s (shouldUpdate=%t)", key, err.Error(), shouldUpdate)
-		} else if updateTime <= 0 {
-			shouldUpdate = true
-			log.Printf("vault-controller: %s: %s renew buffer (shouldUpdate=%t)", key, updateTime, shouldUpdate)
+			return nil, err
 		}
+		log.Printf("vault-controller: %s: adopted existing secret", key)
+		return &syncResult{reason: ReasonAdopted, secret: updated}, nil
 	}
 
-	if shouldUpdate {
-		renewable, _ := strconv.ParseBool(existing.Annotations[RenewableKey])
-		if renewable {
-			leaseID := existing.Annotations[LeaseIDKey]
-			secret, err := ctrl.tryRenewLease(leaseID)
+	shouldUpdate := force || ctrl.shouldUpdate(key, claim, existing)
+	if !shouldUpdate {
+		return &syncResult{reason: ReasonUpToDate, secret: existing}, nil
+	}
+
+	// renewing the lease of a certificate doesn't extend the certificate, so
+	// tls secrets are always rotated.
+	renewable, _ := strconv.ParseBool(existing.Annotations[RenewableKey])
+	if renewable && claim.Spec.Type != v1.SecretTypeTLS {
+		leaseID := existing.Annotations[LeaseIDKey]
+		secret, err := ctrl.tryRenewLease(claim, leaseID)
+		if err != nil {
+			log.Printf("vault-controller: %s: failed to renew - %s", key, err.Error())
+			ctrl.events.claimEvent(claim, v1.EventTypeWarning, ReasonRenewFailed, "failed to renew lease, rotating: %s", err.Error())
+			return ctrl.rotateSecret(key, claim, existing)
+		}
+
+		log.Printf("vault-controller: %s: lease renewed for %ds", key, secret.LeaseDuration)
+		buffer := (time.Duration(claim.Spec.Renew) * time.Second).Seconds()
+		if buffer == 0 {
+			log.Printf("vault-controller: %s: renew was zero, defaulting to 1h", key)
+			buffer = (time.Hour).Seconds()
+		}
+
+		if float64(secret.LeaseDuration) > buffer {
+			updated, err := ctrl.updateSecretMetadata(secret, existing, claim)
 			if err != nil {
-				log.Printf("vault-controller: %s: failed to renew - %s", key, err.Error())
-				return ctrl.updateSecret(key, claim)
+				return &syncResult{reason: ReasonUpToDate, secret: existing}, err
 			}
+			return &syncResult{reason: ReasonRenewed, secret: updated}, nil
+		}
+		log.Printf("vault-controller: %s: renew duration shorter than renew period, rotating", key)
+	}
+	return ctrl.rotateSecret(key, claim, existing)
+}
 
-			log.Printf("vault-controller: %s: lease renewed for %ds", key, secret.LeaseDuration)
-			buffer := (time.Duration(claim.Spec.Renew) * time.Second).Seconds()
-			if buffer == 0 {
-				log.Printf("vault-controller: %s: renew was zero, defaulting to 1h", key)
-				buffer = (time.Hour).Seconds()
-			}
+func (ctrl *controller) rotateSecret(key string, claim *kube.SecretClaim, existing *v1.Secret) (*syncResult, error) {
+	updated, err := ctrl.updateSecret(key, claim, existing)
+	if err != nil {
+		return &syncResult{reason: ReasonUpToDate, secret: existing}, err
+	}
+	return &syncResult{reason: ReasonRotated, secret: updated}, nil
+}
 
-			if float64(secret.LeaseDuration) > buffer {
-				return ctrl.updateSecretMetadata(secret, existing, claim)
+func (ctrl *controller) shouldUpdate(key string, claim *kube.SecretClaim, existing *v1.Secret) bool {
+	if len(claim.Spec.Data) == 0 {
+		if kv, _ := ctrl.kvMountFor(claim.Spec.Path); kv != nil {
+			changed, err := ctrl.kvVersionChanged(claim, kv, existing.Annotations[KVVersionKey])
+			if err == nil {
+				if changed {
+					log.Printf("vault-controller: %s: kv version changed (shouldUpdate=%t)", key, changed)
+				}
+				return changed
 			}
-			log.Printf("vault-controller: %s: renew duration shorter than renew period, rotating", key)
+			log.Printf("vault-controller: %s: failed to check kv version, using lease expiration: %s", key, err.Error())
 		}
-		return ctrl.updateSecret(key, claim)
 	}
-	return nil
+
+	updateTime, err := ctrl.timeUntilUpdate(key, claim, existing)
+	if err != nil {
+		log.Printf("vault-controller: %s: %s (shouldUpdate=%t)", key, err.Error(), true)
+		return true
+	} else if updateTime <= 0 {
+		log.Printf("vault-controller: %s: %s renew buffer (shouldUpdate=%t)", key, updateTime, true)
+		return true
+	}
+	return false
 }
 
-func (ctrl *controller) tryRenewLease(id string) (*vaultapi.Secret, error) {
+func (ctrl *controller) tryRenewLease(claim *kube.SecretClaim, id string) (*vaultapi.Secret, error) {
 	if id == "" {
 		return nil, errors.New("no lease id")
 	}
-	return ctrl.vclient.Sys().Renew(id, 0)
+	vclient, err := ctrl.clientForClaim(claim)
+	if err != nil {
+		return nil, err
+	}
+	start := time.Now()
+	secret, err := vclient.Sys().Renew(id, 0)
+	observeVault("renew", start, err)
+	return secret, err
 }
 
 func buildSecretAnnotations(secret *vaultapi.Secret, claim *kube.SecretClaim) map[string]string {
@@ -156,19 +397,14 @@ func buildSecretAnnotations(secret *vaultapi.Secret, claim *kube.SecretClaim) ma
 	return annotations
 }
 
-func (ctrl *controller) updateSecretMetadata(secret *vaultapi.Secret, existing *v1.Secret, claim *kube.SecretClaim) error {
+func (ctrl *controller) updateSecretMetadata(secret *vaultapi.Secret, existing *v1.Secret, claim *kube.SecretClaim) (*v1.Secret, error) {
 	updated := &v1.Secret{
-		ObjectMeta: v1.ObjectMeta{
-			Name:      claim.Name,
-			Namespace: claim.Namespace,
-
-			Annotations: buildSecretAnnotations(secret, claim),
-		},
-		Type: existing.Type,
-		Data: existing.Data,
+		ObjectMeta: secretObjectMeta(claim, secret),
+		Type:       existing.Type,
+		Data:       existing.Data,
 	}
-	_, err := ctrl.kclient.Core().Secrets(claim.Namespace).Update(updated)
-	return err
+	copyCertificateAnnotations(updated.Annotations, existing)
+	return ctrl.kclient.Core().Secrets(claim.Namespace).Update(updated)
 }
 
 func (ctrl *controller) DeleteSecret(claim *kube.SecretClaim) error {
@@ -176,70 +412,153 @@ func (ctrl *controller) DeleteSecret(claim *kube.SecretClaim) error {
 	if err != nil {
 		return err
 	}
+	return ctrl.deleteSecret(key, claim, true)
+}
 
-	log.Printf("vault-controller: revoking lease for secret %s", key)
+// deleteSecret deletes the secret for a deleted claim, revoking its lease
+// first if revoke is set and the claim doesn't set skipRevoke.
+func (ctrl *controller) deleteSecret(key string, claim *kube.SecretClaim, revoke bool) error {
 	secret, err := ctrl.kclient.Core().Secrets(claim.Namespace).Get(claim.Name)
+	if kerrors.IsNotFound(err) {
+		log.Printf("vault-controller: %s: secret already deleted", key)
+		return nil
+	}
 	if err != nil {
-		log.Printf("vault-controller: %s: not revoking, failed to get secret for deleted claim: %s", key, err.Error())
-	} else {
-		leaseID, ok := secret.Annotations[LeaseIDKey]
-		if !ok {
-			log.Printf("vault-controller: %s: not revoking, failed to get lease id to revoke", key)
-		} else if leaseID == "" {
-			log.Printf("vault-controller: %s: not revoking, no lease id annotation", key)
-		} else {
-			if err := ctrl.vclient.Sys().Revoke(leaseID); err != nil {
-				log.Printf("vault-controller: %s: failed to revoke lease id %s: %s", key, leaseID, err.Error())
-			} else {
-				log.Printf("vault-controller: %s: revoked lease id %s", key, leaseID)
-			}
-		}
+		return fmt.Errorf("failed to get secret for deleted claim: %s", err.Error())
+	}
+	if !ownedBy(secret, claim) {
+		log.Printf("vault-controller: %s: not deleting secret, it is not managed by the controller", key)
+		return nil
+	}
+
+	if claim.Spec.SkipRevoke {
+		log.Printf("vault-controller: %s: not revoking, skipRevoke is set", key)
+	} else if !revoke {
+		log.Printf("vault-controller: %s: not revoking, gave up revoking the lease", key)
+	} else if err := ctrl.revokeLease(key, claim, secret); err != nil {
+		return err
 	}
 
 	log.Printf("vault-controller: %s: deleting secret", key)
-	return ctrl.kclient.Core().Secrets(claim.Namespace).Delete(claim.Name, &v1.DeleteOptions{})
+	err = ctrl.kclient.Core().Secrets(claim.Namespace).Delete(claim.Name, &v1.DeleteOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	return err
 }
 
-func secretFromVault(claim *kube.SecretClaim, secret *vaultapi.Secret) *v1.Secret {
-	return &v1.Secret{
-		ObjectMeta: v1.ObjectMeta{
-			Name:      claim.Name,
-			Namespace: claim.Namespace,
-
-			Annotations: buildSecretAnnotations(secret, claim),
-		},
-		Type: claim.Spec.Type,
-		Data: dataForSecret(claim, secret),
+// revokeLease revokes the lease of the secret.
+func (ctrl *controller) revokeLease(key string, claim *kube.SecretClaim, secret *v1.Secret) error {
+	leaseID := secret.Annotations[LeaseIDKey]
+	if leaseID == "" {
+		log.Printf("vault-controller: %s: not revoking, no lease id annotation", key)
+		return nil
 	}
+	return ctrl.revoke(key, claim, leaseID)
 }
 
-func (ctrl *controller) createSecret(key string, claim *kube.SecretClaim) error {
-	secret, err := ctrl.secretForClaim(claim)
+// revoke revokes a lease with the vault login of the claim. Claims with
+// their own auth never fall back to the controller token, which could revoke
+// leases their own identity can't; if they can't authenticate, for example
+// because their service account was deleted along with its namespace, the
+// revoke fails until --max-revoke-failures gives up on it.
+func (ctrl *controller) revoke(key string, claim *kube.SecretClaim, leaseID string) error {
+	vclient, err := ctrl.clientForClaim(claim)
 	if err != nil {
-		return err
+		ctrl.events.claimEvent(claim, v1.EventTypeWarning, ReasonRevokeFailed, "failed to authenticate to revoke lease %s: %s", leaseID, err.Error())
+		return &claimError{
+			reason: ReasonRevokeFailed,
+			err:    fmt.Errorf("failed to authenticate to revoke lease id %s: %s", leaseID, err.Error()),
+		}
 	}
-
-	_, err = ctrl.kclient.Core().Secrets(claim.Namespace).Create(secret)
+	if claim.Spec.ServiceAccountName == "" && claim.Spec.AppRoleSecretName == "" {
+		if err := ctrl.leaseAllowed(key, claim, leaseID); err != nil {
+			ctrl.events.claimEvent(claim, v1.EventTypeWarning, ReasonRevokeFailed, "not revoking lease %s: %s", leaseID, err.Error())
+			return &claimError{
+				reason: ReasonRevokeFailed,
+				err:    fmt.Errorf("not revoking lease id %s: %s", leaseID, err.Error()),
+			}
+		}
+	}
+	start := time.Now()
+	err = vclient.Sys().Revoke(leaseID)
+	observeVault("revoke", start, err)
 	if err != nil {
-		return err
+		ctrl.events.claimEvent(claim, v1.EventTypeWarning, ReasonRevokeFailed, "failed to revoke lease %s: %s", leaseID, err.Error())
+		return &claimError{
+			reason: ReasonRevokeFailed,
+			err:    fmt.Errorf("failed to revoke lease id %s: %s", leaseID, err.Error()),
+		}
 	}
+	log.Printf("vault-controller: %s: revoked lease id %s", key, leaseID)
+	ctrl.events.claimEvent(claim, v1.EventTypeNormal, ReasonRevoked, "revoked lease %s", leaseID)
 	return nil
 }
 
-func (ctrl *controller) updateSecret(key string, claim *kube.SecretClaim) error {
-	secret, err := ctrl.secretForClaim(claim)
+// leaseAllowed returns an error unless the lease was issued for a path the
+// claim may read. Lease ids come from an annotation anyone who can edit the
+// secret can change, so the controller token only revokes leases the claim
+// could have been issued.
+func (ctrl *controller) leaseAllowed(key string, claim *kube.SecretClaim, leaseID string) error {
+	if _, err := canonicalPath(leaseID); err != nil {
+		return fmt.Errorf("invalid lease id: %s", err.Error())
+	}
+	i := strings.LastIndex(leaseID, "/")
+	if i < 0 {
+		return errors.New("invalid lease id: it has no path")
+	}
+	return ctrl.readAllowed(key, leaseID[:i], claim)
+}
+
+func secretFromVault(claim *kube.SecretClaim, secret *vaultapi.Secret) (*v1.Secret, error) {
+	data, err := dataForSecret(claim, secret)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	_, err = ctrl.kclient.Core().Secrets(claim.Namespace).Update(secret)
+	meta := secretObjectMeta(claim, secret)
+	if claim.Spec.Type == v1.SecretTypeTLS {
+		annotations, err := certificateAnnotations(data)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range annotations {
+			meta.Annotations[k] = v
+		}
+	}
+
+	return &v1.Secret{
+		ObjectMeta: meta,
+		Type:       claim.Spec.Type,
+		Data:       data,
+	}, nil
+}
+
+func (ctrl *controller) createSecret(key string, claim *kube.SecretClaim) (*v1.Secret, error) {
+	secret, err := ctrl.secretForClaim(claim, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+
+	return ctrl.storeSecret(key, claim, secret, ctrl.kclient.Core().Secrets(claim.Namespace).Create)
+}
+
+// updateSecret replaces the secret of a claim. existing is the secret it
+// replaces if the controller manages it, whose private key may be reused.
+func (ctrl *controller) updateSecret(key string, claim *kube.SecretClaim, existing *v1.Secret) (*v1.Secret, error) {
+	secret, err := ctrl.secretForClaim(claim, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrl.storeSecret(key, claim, secret, ctrl.kclient.Core().Secrets(claim.Namespace).Update)
 }
 
 func (ctrl *controller) timeUntilUpdate(key string, claim *kube.SecretClaim, existing *v1.Secret) (time.Duration, error) {
+	if claim.Spec.Type == v1.SecretTypeTLS {
+		return timeUntilRotation(key, claim, existing)
+	}
+
 	leaseExpirationString, ok := existing.Annotations[LeaseExpirationKey]
 	if !ok {
 		return 0, errors.New("needs update, failed to parse lease expiration")
@@ -256,21 +575,41 @@ func (ctrl *controller) timeUntilUpdate(key string, claim *kube.SecretClaim, exi
 		renew = time.Hour
 	}
 
-	buffer := time.Now().Add(renew)
+	buffer := timeNow().Add(renew)
 	expiration := time.Unix(leaseExpiration, 0)
 	return expiration.Sub(buffer), nil
 }
 
-func (ctrl *controller) secretForClaim(claim *kube.SecretClaim) (*v1.Secret, error) {
-	log.Printf("TODO: support authentication per secret")
-	logical := ctrl.vclient.Logical()
+func (ctrl *controller) secretForClaim(claim *kube.SecretClaim, existing *v1.Secret) (*v1.Secret, error) {
+	vclient, err := ctrl.clientForClaim(claim)
+	if err != nil {
+		return nil, err
+	}
+	logical := vclient.Logical()
 
-	var err error
 	var value *vaultapi.Secret
-	if claim.Spec.Data != nil && len(claim.Spec.Data) > 0 {
+	var kvVersion int
+	start := time.Now()
+	if claim.Spec.PrivateKey != nil {
+		value, err = signCertificate(logical, claim, existing)
+	} else if len(claim.Spec.Data) > 0 {
+		// claims with data write it, createOrUpdateSecret checks the path is
+		// one of the write paths first.
 		value, err = logical.Write(claim.Spec.Path, claim.Spec.Data)
+		observeVault("write", start, err)
+	} else if kv, mountsErr := ctrl.kvMountFor(claim.Spec.Path); kv != nil {
+		start = time.Now()
+		value, kvVersion, err = readKV(vclient, kv, claim.Spec.Version)
+		observeVault("read", start, err)
+	} else if claim.Spec.Version != 0 {
+		return nil, fmt.Errorf("version is only supported on kv version 2 mounts, %s is not", claim.Spec.Path)
 	} else {
+		if mountsErr != nil {
+			// a kv version 2 secret read this way has data and metadata keys.
+			ctrl.events.claimEvent(claim, v1.EventTypeWarning, ReasonKVVersionUnknown, "reading %s as kv version 1, the kv version of its mount is unknown: %s", claim.Spec.Path, mountsErr.Error())
+		}
 		value, err = logical.Read(claim.Spec.Path)
+		observeVault("read", start, err)
 	}
 
 	if err != nil {
@@ -279,21 +618,46 @@ func (ctrl *controller) secretForClaim(claim *kube.SecretClaim) (*v1.Secret, err
 	if value == nil {
 		return nil, fmt.Errorf("no secret found for %s", claim.Spec.Path)
 	}
+	if err := ctrl.trackIssued(claim, value.LeaseID); err != nil {
+		return nil, err
+	}
 
-	return secretFromVault(claim, value), nil
+	secret, err := secretFromVault(claim, value)
+	if err != nil {
+		ctrl.releaseIssued(claim, value.LeaseID)
+		return nil, err
+	}
+	if kvVersion > 0 {
+		secret.Annotations[KVVersionKey] = strconv.Itoa(kvVersion)
+	}
+	return secret, nil
 }
 
-func dataForSecret(claim *kube.SecretClaim, secret *vaultapi.Secret) map[string][]byte {
-	data := make(map[string][]byte, len(secret.Data))
+func dataForSecret(claim *kube.SecretClaim, secret *vaultapi.Secret) (map[string][]byte, error) {
+	var data map[string][]byte
 	switch claim.Spec.Type {
 	case v1.SecretTypeTLS:
-		data[v1.TLSCertKey] = []byte(secret.Data[PKICertificateKey].(string))
-		data[v1.TLSPrivateKeyKey] = []byte(secret.Data[PKIPrivateKeyKey].(string))
+		bundle, err := parseCertBundle(secret.Data)
+		if err != nil {
+			return nil, err
+		}
+		data = tlsData(bundle, claim.Spec.FullChain)
 	default:
-		for key, val := range secret.Data {
-			datom, _ := val.(string)
-			data[key] = []byte(datom)
+		var err error
+		data, err = encodeData(secret.Data, claim.Spec.Flatten)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(claim.Spec.Template) > 0 {
+		rendered, err := renderTemplates(claim, secret)
+		if err != nil {
+			return nil, err
+		}
+		for key, val := range rendered {
+			data[key] = val
 		}
 	}
-	return data
+	return data, nil
 }
@@ -2,11 +2,13 @@ package vault
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
 	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
 )
 
 func init() {
@@ -142,3 +144,147 @@ func Test_pathAllowed(t *testing.T) {
 		})
 	}
 }
+
+func Test_timeUntilSync(t *testing.T) {
+	expiresIn := func(d time.Duration, annotations map[string]string) *v1.Secret {
+		secret := &v1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Annotations: map[string]string{
+					LeaseExpirationKey: strconv.FormatInt(timeNow().Add(d).Unix(), 10),
+				},
+			},
+		}
+		for k, v := range annotations {
+			secret.Annotations[k] = v
+		}
+		return secret
+	}
+
+	tests := []struct {
+		name    string
+		renew   int64
+		version int
+		secret  *v1.Secret
+		want    time.Duration
+	}{
+		{
+			name:   "lease expiration minus default renew buffer",
+			secret: expiresIn(3*time.Hour, nil),
+			want:   2 * time.Hour,
+		},
+		{
+			name:   "lease expiration minus claimed renew buffer",
+			renew:  600,
+			secret: expiresIn(time.Hour, nil),
+			want:   50 * time.Minute,
+		},
+		{
+			name:   "lease shorter than renew buffer",
+			secret: expiresIn(30*time.Minute, nil),
+			want:   0,
+		},
+		{
+			name:   "unpinned kv version 2 secret",
+			secret: expiresIn(3*time.Hour, map[string]string{KVVersionKey: "3"}),
+			want:   kvVersionCheckInterval,
+		},
+		{
+			name:    "pinned kv version 2 secret",
+			version: 3,
+			secret:  expiresIn(3*time.Hour, map[string]string{KVVersionKey: "3"}),
+			want:    0,
+		},
+		{
+			name:   "no lease expiration",
+			secret: &v1.Secret{},
+			want:   0,
+		},
+		{
+			name: "no secret",
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &controller{}
+			claim := &kube.SecretClaim{Spec: kube.SecretSpec{Renew: tt.renew, Version: tt.version}}
+			if got := ctrl.timeUntilSync("ns/claim", claim, tt.secret); got != tt.want {
+				t.Errorf("timeUntilSync() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_writeAllowed(t *testing.T) {
+	writePolicy := &PathPolicy{
+		Default: EffectDeny,
+		Rules: []PathRule{
+			{Path: "pki/issue/*", Effect: EffectAllow},
+			{Path: "transit/encrypt/{{namespace}}", Effect: EffectAllow},
+		},
+	}
+	tests := []struct {
+		name          string
+		path          string
+		disableWrites bool
+		want          bool
+	}{
+		{name: "allowed path", path: "pki/issue/example", want: true},
+		{name: "allowed path of namespace", path: "transit/encrypt/namespace", want: true},
+		{name: "path of other namespace", path: "transit/encrypt/other", want: false},
+		{name: "path outside the write paths", path: "secret/example", want: false},
+		{name: "star doesn't cross segments", path: "pki/issue/example/extra", want: false},
+		{name: "writes disabled", path: "pki/issue/example", disableWrites: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &controller{writePolicy: writePolicy, disableWrites: tt.disableWrites}
+			claim := &kube.SecretClaim{}
+			claim.Namespace = "namespace"
+			claim.Name = "claim"
+			err := ctrl.writeAllowed("namespace/claim", tt.path, claim)
+			if (err == nil) != tt.want {
+				t.Fatalf("writeAllowed() error = %v, want allowed %t", err, tt.want)
+			}
+			if err != nil && reasonForError(err) != ReasonWriteDenied {
+				t.Errorf("reasonForError() = %q, want %q", reasonForError(err), ReasonWriteDenied)
+			}
+		})
+	}
+}
+
+func Test_leaseAllowed(t *testing.T) {
+	pathPolicy := &PathPolicy{
+		Default: EffectDeny,
+		Rules: []PathRule{
+			{Path: "database/creds/{{namespace}}-*", Effect: EffectAllow},
+		},
+	}
+	tests := []struct {
+		name            string
+		namespacePrefix string
+		pathPolicy      *PathPolicy
+		leaseID         string
+		want            bool
+	}{
+		{name: "no restrictions", leaseID: "database/creds/other-app/1", want: true},
+		{name: "in the namespace prefix", namespacePrefix: "secret/", leaseID: "secret/namespace/app/1", want: true},
+		{name: "other namespace of the namespace prefix", namespacePrefix: "secret/", leaseID: "secret/other/app/1", want: false},
+		{name: "allowed by the path policy", pathPolicy: pathPolicy, leaseID: "database/creds/namespace-app/1", want: true},
+		{name: "denied by the path policy", pathPolicy: pathPolicy, leaseID: "database/creds/other-app/1", want: false},
+		{name: "path policy over namespace prefix", namespacePrefix: "database/", pathPolicy: pathPolicy, leaseID: "database/creds/namespace-app/1", want: true},
+		{name: "no path", leaseID: "1", want: false},
+		{name: "dot dot segment", leaseID: "secret/namespace/../other/app/1", namespacePrefix: "secret/", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := &controller{namespacePrefix: tt.namespacePrefix, pathPolicy: tt.pathPolicy}
+			claim := &kube.SecretClaim{}
+			claim.Namespace = "namespace"
+			claim.Name = "claim"
+			if err := ctrl.leaseAllowed("namespace/claim", claim, tt.leaseID); (err == nil) != tt.want {
+				t.Errorf("leaseAllowed() error = %v, want allowed %t", err, tt.want)
+			}
+		})
+	}
+}
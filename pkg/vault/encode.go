@@ -0,0 +1,77 @@
+package vault
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var secretKeyRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// encodeData encodes vault response data as secret data. Scalars are encoded
+// as text and composite values as json, unless flatten is set in which case
+// nested maps become keys joined with dots.
+func encodeData(data map[string]interface{}, flatten bool) (map[string][]byte, error) {
+	encoded := make(map[string][]byte, len(data))
+	if err := encodeInto(encoded, "", data, flatten); err != nil {
+		return nil, err
+	}
+	return encoded, nil
+}
+
+func encodeInto(encoded map[string][]byte, prefix string, data map[string]interface{}, flatten bool) error {
+	for key, val := range data {
+		key = prefix + key
+
+		if nested, ok := val.(map[string]interface{}); ok && flatten {
+			if err := encodeInto(encoded, key+".", nested, flatten); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !secretKeyRegexp.MatchString(key) {
+			return fmt.Errorf("key %q is not a valid secret key", key)
+		}
+		if _, exists := encoded[key]; exists {
+			return fmt.Errorf("key %q is duplicated after flattening", key)
+		}
+
+		datom, err := encodeValue(val)
+		if err != nil {
+			return fmt.Errorf("key %q: %s", key, err.Error())
+		}
+		encoded[key] = datom
+	}
+	return nil
+}
+
+// encodeValue encodes a single value from a vault response.
+func encodeValue(val interface{}) ([]byte, error) {
+	switch v := val.(type) {
+	case nil:
+		return []byte{}, nil
+	case string:
+		return []byte(v), nil
+	case []byte:
+		return v, nil
+	case bool:
+		return []byte(strconv.FormatBool(v)), nil
+	case json.Number:
+		return []byte(v.String()), nil
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	case int:
+		return []byte(strconv.Itoa(v)), nil
+	case int64:
+		return []byte(strconv.FormatInt(v, 10)), nil
+	case map[string]interface{}, []interface{}:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("value can't be encoded as json: %s", err.Error())
+		}
+		return raw, nil
+	}
+	return nil, fmt.Errorf("value of type %T can't be encoded", val)
+}
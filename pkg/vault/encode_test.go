@@ -0,0 +1,101 @@
+package vault
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_encodeData(t *testing.T) {
+	data := map[string]interface{}{
+		"string":  "value",
+		"number":  json.Number("5432"),
+		"float":   1.5,
+		"integer": float64(30),
+		"bool":    true,
+		"null":    nil,
+		"list":    []interface{}{"a", json.Number("1")},
+		"nested": map[string]interface{}{
+			"user": "app",
+			"deeper": map[string]interface{}{
+				"port": json.Number("80"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		flatten bool
+		want    map[string][]byte
+		wantErr bool
+	}{
+		{
+			name: "scalars as text and composites as json",
+			data: data,
+			want: map[string][]byte{
+				"string":  []byte("value"),
+				"number":  []byte("5432"),
+				"float":   []byte("1.5"),
+				"integer": []byte("30"),
+				"bool":    []byte("true"),
+				"null":    []byte{},
+				"list":    []byte(`["a",1]`),
+				"nested":  []byte(`{"deeper":{"port":80},"user":"app"}`),
+			},
+		},
+		{
+			name:    "flatten nested maps",
+			data:    data,
+			flatten: true,
+			want: map[string][]byte{
+				"string":             []byte("value"),
+				"number":             []byte("5432"),
+				"float":              []byte("1.5"),
+				"integer":            []byte("30"),
+				"bool":               []byte("true"),
+				"null":               []byte{},
+				"list":               []byte(`["a",1]`),
+				"nested.user":        []byte("app"),
+				"nested.deeper.port": []byte("80"),
+			},
+		},
+		{
+			name: "invalid secret key",
+			data: map[string]interface{}{
+				"not/valid": "value",
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate key after flattening",
+			data: map[string]interface{}{
+				"a.b": "one",
+				"a": map[string]interface{}{
+					"b": "two",
+				},
+			},
+			flatten: true,
+			wantErr: true,
+		},
+		{
+			name: "value that can't be encoded",
+			data: map[string]interface{}{
+				"nan": []interface{}{math.NaN()},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeData(tt.data, tt.flatten)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encodeData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("encodeData() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
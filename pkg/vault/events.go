@@ -0,0 +1,141 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/pkg/api/unversioned"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+// Reasons only used for events, the other reasons are shared with claim
+// conditions.
+const (
+	ReasonRenewFailed  = "RenewFailed"
+	ReasonRevoked      = "Revoked"
+	ReasonRevokeFailed = "RevokeFailed"
+	// ReasonRevokeAbandoned is recorded when the secret of a deleted claim
+	// is deleted without revoking its lease, after revoking it kept failing.
+	ReasonRevokeAbandoned = "RevokeAbandoned"
+	// ReasonKVVersionUnknown is recorded when a claim is read as kv version
+	// 1 because sys/mounts could not be read.
+	ReasonKVVersionUnknown = "KVVersionUnknown"
+)
+
+// eventComponent is the source component of events.
+const eventComponent = "kube-vault-controller"
+
+// eventRecorder records kubernetes events on claims and their secrets.
+// Failing to record an event is logged and otherwise ignored.
+type eventRecorder struct {
+	client *kubernetes.Clientset
+	host   string
+}
+
+func newEventRecorder(client *kubernetes.Clientset) *eventRecorder {
+	host, _ := os.Hostname()
+	return &eventRecorder{client: client, host: host}
+}
+
+// claimEvent records an event on a claim. Claims without a uid, such as the
+// stand-ins for orphaned secrets, get no events.
+func (r *eventRecorder) claimEvent(claim *kube.SecretClaim, eventType, reason, format string, args ...interface{}) {
+	if claim.UID == "" {
+		return
+	}
+	r.record(v1.ObjectReference{
+		APIVersion:      kube.GroupVersion.String(),
+		Kind:            "SecretClaim",
+		Namespace:       claim.Namespace,
+		Name:            claim.Name,
+		UID:             claim.UID,
+		ResourceVersion: claim.ResourceVersion,
+	}, eventType, reason, fmt.Sprintf(format, args...))
+}
+
+// secretEvent records an event on a secret.
+func (r *eventRecorder) secretEvent(secret *v1.Secret, eventType, reason, format string, args ...interface{}) {
+	if secret == nil || secret.UID == "" {
+		return
+	}
+	r.record(v1.ObjectReference{
+		APIVersion:      "v1",
+		Kind:            "Secret",
+		Namespace:       secret.Namespace,
+		Name:            secret.Name,
+		UID:             secret.UID,
+		ResourceVersion: secret.ResourceVersion,
+	}, eventType, reason, fmt.Sprintf(format, args...))
+}
+
+func (r *eventRecorder) record(ref v1.ObjectReference, eventType, reason, message string) {
+	if r == nil || r.client == nil {
+		return
+	}
+
+	now := timeNow()
+	event := &v1.Event{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", ref.Name, now.UnixNano()),
+			Namespace: ref.Namespace,
+		},
+		InvolvedObject: ref,
+		Reason:         reason,
+		Message:        message,
+		Source: v1.EventSource{
+			Component: eventComponent,
+			Host:      r.host,
+		},
+		FirstTimestamp: unversioned.NewTime(now),
+		LastTimestamp:  unversioned.NewTime(now),
+		Count:          1,
+		Type:           eventType,
+	}
+	if _, err := r.client.Core().Events(ref.Namespace).Create(event); err != nil {
+		log.Printf("vault-controller: %s/%s: failed to record %s event: %s", ref.Namespace, ref.Name, reason, err.Error())
+	}
+}
+
+// recordSync records the outcome of a sync. Syncs that didn't change the
+// secret record nothing, and a failure is only recorded when it differs from
+// the failure already reported on the claim, so retries don't repeat it.
+func (ctrl *controller) recordSync(claim *kube.SecretClaim, result *syncResult, syncErr error) {
+	eventType, reason, message, ok := syncEvent(claim, result, syncErr)
+	if !ok {
+		return
+	}
+	ctrl.events.claimEvent(claim, eventType, reason, "%s", message)
+	if syncErr == nil {
+		ctrl.events.secretEvent(result.secret, eventType, reason, "%s", message)
+	}
+}
+
+func syncEvent(claim *kube.SecretClaim, result *syncResult, syncErr error) (eventType, reason, message string, ok bool) {
+	if syncErr != nil {
+		reason = reasonForError(syncErr)
+		for _, condition := range claim.Status.Conditions {
+			if condition.Type == kube.SecretClaimSynced && condition.Status == v1.ConditionFalse &&
+				condition.Reason == reason && condition.Message == syncErr.Error() {
+				return "", "", "", false
+			}
+		}
+		return v1.EventTypeWarning, reason, syncErr.Error(), true
+	}
+
+	switch result.reason {
+	case ReasonCreated:
+		message = fmt.Sprintf("created secret from %s", claim.Spec.Path)
+	case ReasonAdopted:
+		message = fmt.Sprintf("adopted existing secret, backed up to %s%s", claim.Name, backupSuffix)
+	case ReasonRenewed:
+		message = "renewed lease"
+	case ReasonRotated:
+		message = fmt.Sprintf("rotated secret from %s", claim.Spec.Path)
+	default:
+		return "", "", "", false
+	}
+	return v1.EventTypeNormal, result.reason, message, true
+}
@@ -0,0 +1,78 @@
+package vault
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+func Test_syncEvent(t *testing.T) {
+	denied := &claimError{reason: ReasonPathDenied, err: errors.New("denied")}
+	reported := kube.SecretClaimStatus{
+		Conditions: []kube.SecretClaimCondition{
+			{Type: kube.SecretClaimSynced, Status: v1.ConditionFalse, Reason: ReasonPathDenied, Message: "denied"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		status     kube.SecretClaimStatus
+		result     *syncResult
+		err        error
+		wantType   string
+		wantReason string
+		wantOK     bool
+	}{
+		{
+			name:       "created",
+			result:     &syncResult{reason: ReasonCreated},
+			wantType:   v1.EventTypeNormal,
+			wantReason: ReasonCreated,
+			wantOK:     true,
+		},
+		{
+			name:       "renewed",
+			result:     &syncResult{reason: ReasonRenewed},
+			wantType:   v1.EventTypeNormal,
+			wantReason: ReasonRenewed,
+			wantOK:     true,
+		},
+		{
+			name:   "up to date",
+			result: &syncResult{reason: ReasonUpToDate},
+			wantOK: false,
+		},
+		{
+			name:       "new failure",
+			err:        denied,
+			wantType:   v1.EventTypeWarning,
+			wantReason: ReasonPathDenied,
+			wantOK:     true,
+		},
+		{
+			name:   "failure already reported",
+			status: reported,
+			err:    denied,
+			wantOK: false,
+		},
+		{
+			name:       "different failure",
+			status:     reported,
+			err:        errors.New("vault is sealed"),
+			wantType:   v1.EventTypeWarning,
+			wantReason: ReasonSyncFailed,
+			wantOK:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &kube.SecretClaim{Status: tt.status}
+			eventType, reason, _, ok := syncEvent(claim, tt.result, tt.err)
+			if ok != tt.wantOK || eventType != tt.wantType || reason != tt.wantReason {
+				t.Errorf("syncEvent() = %q, %q, %t, want %q, %q, %t", eventType, reason, ok, tt.wantType, tt.wantReason, tt.wantOK)
+			}
+		})
+	}
+}
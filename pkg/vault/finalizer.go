@@ -0,0 +1,125 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/pkg/types"
+)
+
+// Finalizer keeps a deleted claim around until its lease is revoked and its
+// secret is deleted, even if the controller wasn't running when it was
+// deleted.
+const Finalizer = "vaultproject.io/revoke-lease"
+
+// ensureFinalizer adds the finalizer to the claim if it's missing, returning
+// the updated claim.
+func (ctrl *controller) ensureFinalizer(key string, claim *kube.SecretClaim) (*kube.SecretClaim, error) {
+	if hasFinalizer(claim) {
+		return claim, nil
+	}
+
+	updated := *claim
+	updated.Finalizers = append(append([]string(nil), claim.Finalizers...), Finalizer)
+	result, err := ctrl.updateClaim(&updated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add finalizer: %s", err.Error())
+	}
+	log.Printf("vault-controller: %s: added finalizer %s", key, Finalizer)
+	return result, nil
+}
+
+// finalize cleans up after a deleted claim and removes the finalizer, which
+// lets the claim be deleted. Once revoking the lease failed maxRevokeFailures
+// times, the secret is deleted without revoking it and the lease expires on
+// its own.
+func (ctrl *controller) finalize(key string, claim *kube.SecretClaim) error {
+	if !hasFinalizer(claim) {
+		return nil
+	}
+
+	log.Printf("vault-controller: %s: finalizing deleted claim", key)
+	revoke := true
+	failures := ctrl.revokeFailures.get(claim.UID)
+	if !claim.Spec.SkipRevoke && ctrl.maxRevokeFailures > 0 && failures >= ctrl.maxRevokeFailures {
+		log.Printf("vault-controller: %s: giving up revoking the lease after %d failures", key, failures)
+		ctrl.events.claimEvent(claim, v1.EventTypeWarning, ReasonRevokeAbandoned, "gave up revoking the lease after %d failures, it expires on its own", failures)
+		revoke = false
+	}
+	if err := ctrl.deleteSecret(key, claim, revoke); err != nil {
+		if reasonForError(err) == ReasonRevokeFailed {
+			ctrl.revokeFailures.add(claim.UID)
+		}
+		return err
+	}
+
+	updated := *claim
+	updated.Finalizers = nil
+	for _, finalizer := range claim.Finalizers {
+		if finalizer != Finalizer {
+			updated.Finalizers = append(updated.Finalizers, finalizer)
+		}
+	}
+	if _, err := ctrl.updateClaim(&updated); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %s", err.Error())
+	}
+	log.Printf("vault-controller: %s: removed finalizer %s", key, Finalizer)
+	ctrl.revokeFailures.forget(claim.UID)
+	return nil
+}
+
+func (ctrl *controller) updateClaim(claim *kube.SecretClaim) (*kube.SecretClaim, error) {
+	result := &kube.SecretClaim{}
+	err := ctrl.claims.Put().
+		Namespace(claim.Namespace).
+		Resource(kube.ResourceSecretClaims).
+		Name(claim.Name).
+		Body(claim).
+		Do().
+		Into(result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func hasFinalizer(claim *kube.SecretClaim) bool {
+	for _, finalizer := range claim.Finalizers {
+		if finalizer == Finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// revokeFailures counts the failed revocations of deleted claims by uid.
+// Counts are lost on restart, which only delays giving up.
+type revokeFailures struct {
+	lock   sync.Mutex
+	counts map[types.UID]int
+}
+
+func newRevokeFailures() *revokeFailures {
+	return &revokeFailures{counts: map[types.UID]int{}}
+}
+
+func (f *revokeFailures) get(uid types.UID) int {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.counts[uid]
+}
+
+func (f *revokeFailures) add(uid types.UID) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.counts[uid]++
+}
+
+func (f *revokeFailures) forget(uid types.UID) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.counts, uid)
+}
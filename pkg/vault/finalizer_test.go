@@ -0,0 +1,254 @@
+package vault
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/unversioned"
+)
+
+const (
+	putClaim     = "PUT /apis/vaultproject.io/v1/namespaces/example/secretclaims/app"
+	getSecret    = "GET /api/v1/namespaces/example/secrets/app"
+	deleteSecret = "DELETE /api/v1/namespaces/example/secrets/app"
+	postEvent    = "POST /api/v1/namespaces/example/events"
+)
+
+// serveFinalizerAPI serves a managed secret for the claim from vault. The
+// claims updated through it are recorded in claims and the reasons of the
+// events posted to it in reasons.
+func serveFinalizerAPI(t *testing.T, vault *fakeVault, claims *[]*kube.SecretClaim, reasons *[]string) {
+	vault.respond(getSecret, http.StatusOK, `{"kind":"Secret","apiVersion":"v1","metadata":{"name":"app","namespace":"example",`+
+		`"labels":{"app.kubernetes.io/managed-by":"kube-vault-controller"},"annotations":{"vaultproject.io/lease-id":"database/creds/app/1"}}}`)
+	vault.respond(deleteSecret, http.StatusOK, `{"kind":"Status","apiVersion":"v1","status":"Success"}`)
+
+	var mu sync.Mutex
+	vault.handle(putClaim, func(w http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		claim := &kube.SecretClaim{}
+		if err := json.Unmarshal(body, claim); err != nil {
+			t.Errorf("claim update: %s", err.Error())
+		}
+		mu.Lock()
+		*claims = append(*claims, claim)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+	vault.handle(postEvent, func(w http.ResponseWriter, req *http.Request) {
+		event := map[string]interface{}{}
+		json.NewDecoder(req.Body).Decode(&event)
+		mu.Lock()
+		*reasons = append(*reasons, event["reason"].(string))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(event)
+	})
+}
+
+func finalizerClaim(finalizers ...string) *kube.SecretClaim {
+	return &kube.SecretClaim{
+		TypeMeta:   unversioned.TypeMeta{Kind: "SecretClaim", APIVersion: kube.APIGroupVersion},
+		ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example", UID: "1234", Finalizers: finalizers},
+		Spec:       kube.SecretSpec{Path: "database/creds/app"},
+	}
+}
+
+func Test_ensureFinalizer(t *testing.T) {
+	tests := []struct {
+		name       string
+		finalizers []string
+		want       []string
+		wantUpdate bool
+	}{
+		{name: "adds finalizer", finalizers: []string{"other"}, want: []string{"other", Finalizer}, wantUpdate: true},
+		{name: "has finalizer", finalizers: []string{Finalizer}, want: []string{Finalizer}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := newFakeVault(t)
+			defer vault.Close()
+			var updated []*kube.SecretClaim
+			var reasons []string
+			serveFinalizerAPI(t, vault, &updated, &reasons)
+			ctrl := newTestController(t, vault)
+
+			claim := finalizerClaim(tt.finalizers...)
+			got, err := ctrl.ensureFinalizer("example/app", claim)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.Finalizers, tt.want) {
+				t.Errorf("ensureFinalizer() finalizers = %v, want %v", got.Finalizers, tt.want)
+			}
+			if (len(updated) == 1) != tt.wantUpdate {
+				t.Errorf("ensureFinalizer() made %d updates, wantUpdate %t", len(updated), tt.wantUpdate)
+			}
+			if !reflect.DeepEqual(claim.Finalizers, tt.finalizers) {
+				t.Errorf("ensureFinalizer() changed the finalizers of the claim to %v", claim.Finalizers)
+			}
+		})
+	}
+}
+
+func Test_finalize(t *testing.T) {
+	tests := []struct {
+		name              string
+		finalizers        []string
+		skipRevoke        bool
+		namespacePrefix   string
+		serviceAccount    string
+		revokeStatus      int
+		failures          int
+		maxRevokeFailures int
+		wantErr           bool
+		wantRevokes       int
+		wantRemoved       bool
+		wantFailures      int
+		wantAbandoned     bool
+	}{
+		{
+			name:         "no finalizer",
+			finalizers:   []string{"other"},
+			revokeStatus: http.StatusNoContent,
+		},
+		{
+			name:         "revokes and removes finalizer",
+			finalizers:   []string{"other", Finalizer},
+			revokeStatus: http.StatusNoContent,
+			wantRevokes:  1,
+			wantRemoved:  true,
+		},
+		{
+			name:              "revoke fails",
+			finalizers:        []string{"other", Finalizer},
+			revokeStatus:      http.StatusInternalServerError,
+			maxRevokeFailures: 3,
+			wantErr:           true,
+			wantRevokes:       1,
+			wantFailures:      1,
+		},
+		{
+			name:              "revoke fails below max failures",
+			finalizers:        []string{"other", Finalizer},
+			revokeStatus:      http.StatusInternalServerError,
+			failures:          2,
+			maxRevokeFailures: 3,
+			wantErr:           true,
+			wantRevokes:       1,
+			wantFailures:      3,
+		},
+		{
+			name:              "skipRevoke set after revoke failed",
+			finalizers:        []string{"other", Finalizer},
+			skipRevoke:        true,
+			revokeStatus:      http.StatusInternalServerError,
+			failures:          2,
+			maxRevokeFailures: 3,
+			wantRemoved:       true,
+		},
+		{
+			name:              "gives up after max failures",
+			finalizers:        []string{"other", Finalizer},
+			revokeStatus:      http.StatusInternalServerError,
+			failures:          3,
+			maxRevokeFailures: 3,
+			wantRemoved:       true,
+			wantAbandoned:     true,
+		},
+		{
+			name:              "lease outside the namespace prefix",
+			finalizers:        []string{"other", Finalizer},
+			namespacePrefix:   "database/",
+			revokeStatus:      http.StatusNoContent,
+			maxRevokeFailures: 3,
+			wantErr:           true,
+			wantFailures:      1,
+		},
+		{
+			name:              "claim can't authenticate",
+			finalizers:        []string{"other", Finalizer},
+			serviceAccount:    "deleted",
+			revokeStatus:      http.StatusNoContent,
+			maxRevokeFailures: 3,
+			wantErr:           true,
+			wantFailures:      1,
+		},
+		{
+			name:              "gives up when claim can't authenticate",
+			finalizers:        []string{"other", Finalizer},
+			serviceAccount:    "deleted",
+			revokeStatus:      http.StatusNoContent,
+			failures:          3,
+			maxRevokeFailures: 3,
+			wantRemoved:       true,
+			wantAbandoned:     true,
+		},
+		{
+			name:         "retries forever without max failures",
+			finalizers:   []string{"other", Finalizer},
+			revokeStatus: http.StatusInternalServerError,
+			failures:     100,
+			wantErr:      true,
+			wantRevokes:  1,
+			wantFailures: 101,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := newFakeVault(t)
+			defer vault.Close()
+			var updated []*kube.SecretClaim
+			var reasons []string
+			serveFinalizerAPI(t, vault, &updated, &reasons)
+			ctrl := newTestController(t, vault)
+			ctrl.events = &eventRecorder{client: ctrl.kclient}
+			ctrl.revokeFailures = newRevokeFailures()
+			ctrl.maxRevokeFailures = tt.maxRevokeFailures
+			ctrl.namespacePrefix = tt.namespacePrefix
+			vault.respond(revokeLease, tt.revokeStatus, `{"errors":["internal error"]}`)
+
+			claim := finalizerClaim(tt.finalizers...)
+			claim.Spec.SkipRevoke = tt.skipRevoke
+			claim.Spec.ServiceAccountName = tt.serviceAccount
+			for i := 0; i < tt.failures; i++ {
+				ctrl.revokeFailures.add(claim.UID)
+			}
+
+			err := ctrl.finalize("example/app", claim)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("finalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(vault.requests(revokeLease)); got != tt.wantRevokes {
+				t.Errorf("finalize() revoked %d times, want %d", got, tt.wantRevokes)
+			}
+			if tt.wantRemoved {
+				if len(updated) != 1 || !reflect.DeepEqual(updated[0].Finalizers, []string{"other"}) {
+					t.Errorf("finalize() updated claims %v, want the finalizer removed", updated)
+				}
+				if got := len(vault.requests(deleteSecret)); got != 1 {
+					t.Errorf("finalize() deleted the secret %d times, want 1", got)
+				}
+			} else if len(updated) != 0 || len(vault.requests(deleteSecret)) != 0 {
+				t.Errorf("finalize() updated the claim or deleted the secret, want neither")
+			}
+			if got := ctrl.revokeFailures.get(claim.UID); got != tt.wantFailures {
+				t.Errorf("finalize() failures = %d, want %d", got, tt.wantFailures)
+			}
+			abandoned := false
+			for _, reason := range reasons {
+				abandoned = abandoned || reason == ReasonRevokeAbandoned
+			}
+			if abandoned != tt.wantAbandoned {
+				t.Errorf("finalize() events = %v, wantAbandoned %t", reasons, tt.wantAbandoned)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// errShuttingDown is returned for leases issued after the controller started
+// revoking unstored leases, which are revoked instead of stored.
+var errShuttingDown = errors.New("controller is shutting down, the lease was revoked instead of stored")
+
+// issuedLeases tracks leases vault issued that are not stored in a secret
+// yet. Nothing else records them, so they are revoked if storing the secret
+// fails or the controller shuts down before it is stored.
+type issuedLeases struct {
+	lock   sync.Mutex
+	leases map[string]*issuedLease
+	// drained is set once the leases were revoked on shutdown. Leases issued
+	// after that are revoked right away.
+	drained bool
+}
+
+type issuedLease struct {
+	claim *kube.SecretClaim
+	// storing is set while the secret holding the lease is being stored.
+	storing bool
+}
+
+func newIssuedLeases() *issuedLeases {
+	return &issuedLeases{leases: map[string]*issuedLease{}}
+}
+
+// add tracks a lease as soon as vault issued it. It returns false if the
+// leases were drained, and the caller must revoke it.
+func (l *issuedLeases) add(leaseID string, claim *kube.SecretClaim) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.drained {
+		return false
+	}
+	l.leases[leaseID] = &issuedLease{claim: claim}
+	return true
+}
+
+// store marks a lease as being stored, so it is left to the store call when
+// the leases are drained. It returns false if the lease was drained, and
+// must not be stored.
+func (l *issuedLeases) store(leaseID string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	lease, ok := l.leases[leaseID]
+	if !ok {
+		return false
+	}
+	lease.storing = true
+	return true
+}
+
+// remove stops tracking a lease, returning false if it was drained.
+func (l *issuedLeases) remove(leaseID string) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	_, ok := l.leases[leaseID]
+	delete(l.leases, leaseID)
+	return ok
+}
+
+// drain stops tracking leases, returning the ones to revoke and the number
+// left to store calls in flight. Leases issued after it are not tracked.
+func (l *issuedLeases) drain() (map[string]*kube.SecretClaim, int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	revoke := map[string]*kube.SecretClaim{}
+	storing := 0
+	for leaseID, lease := range l.leases {
+		if lease.storing {
+			storing++
+			continue
+		}
+		revoke[leaseID] = lease.claim
+		delete(l.leases, leaseID)
+	}
+	l.drained = true
+	return revoke, storing
+}
+
+// trackIssued tracks a lease vault issued for a claim, revoking it if the
+// controller is shutting down.
+func (ctrl *controller) trackIssued(claim *kube.SecretClaim, leaseID string) error {
+	if leaseID == "" || ctrl.issued.add(leaseID, claim) {
+		return nil
+	}
+	ctrl.revokeIssued(claim, leaseID)
+	return errShuttingDown
+}
+
+// releaseIssued revokes a lease vault issued that won't be stored, unless it
+// was revoked on shutdown already.
+func (ctrl *controller) releaseIssued(claim *kube.SecretClaim, leaseID string) {
+	if leaseID != "" && ctrl.issued.remove(leaseID) {
+		ctrl.revokeIssued(claim, leaseID)
+	}
+}
+
+// storeSecret stores a secret read from vault with store, revoking its lease
+// if it can't be stored. The lease was tracked by secretForClaim.
+func (ctrl *controller) storeSecret(key string, claim *kube.SecretClaim, secret *v1.Secret, store func(*v1.Secret) (*v1.Secret, error)) (*v1.Secret, error) {
+	leaseID := secret.Annotations[LeaseIDKey]
+	if leaseID == "" {
+		return store(secret)
+	}
+
+	if !ctrl.issued.store(leaseID) {
+		return nil, errShuttingDown
+	}
+	stored, err := store(secret)
+	ctrl.issued.remove(leaseID)
+	if err != nil {
+		ctrl.revokeIssued(claim, leaseID)
+	}
+	return stored, err
+}
+
+func (ctrl *controller) revokeIssued(claim *kube.SecretClaim, leaseID string) {
+	key, err := cache.MetaNamespaceKeyFunc(claim)
+	if err != nil {
+		return
+	}
+
+	log.Printf("vault-controller: %s: revoking lease id %s, it was not stored", key, leaseID)
+	if err := ctrl.revoke(key, claim, leaseID); err != nil {
+		log.Printf("vault-controller: %s: %s", key, err.Error())
+	}
+}
+
+// RevokeIssued revokes the leases that are not stored, and has syncs still
+// in flight revoke the leases vault issues them from now on. Leases that are
+// being stored are left to the store call, which revokes them if it fails.
+func (ctrl *controller) RevokeIssued() {
+	leases, storing := ctrl.issued.drain()
+	for leaseID, claim := range leases {
+		ctrl.revokeIssued(claim, leaseID)
+	}
+	if storing > 0 {
+		log.Printf("vault-controller: %d leases are being stored, they are revoked if storing them fails", storing)
+	}
+}
@@ -0,0 +1,170 @@
+package vault
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"k8s.io/client-go/pkg/api"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+const (
+	readCreds   = "GET /v1/database/creds/app"
+	revokeLease = "PUT /v1/sys/revoke/database/creds/app/1"
+)
+
+// serveCreds serves a database lease for credsClaim from vault.
+func serveCreds(vault *fakeVault) {
+	vault.respond(sysMounts, http.StatusOK, `{}`)
+	vault.respond(readCreds, http.StatusOK, `{"lease_id":"database/creds/app/1","lease_duration":3600,"renewable":true,"data":{"username":"app"}}`)
+	vault.respond(revokeLease, http.StatusNoContent, ``)
+}
+
+func credsClaim() *kube.SecretClaim {
+	return &kube.SecretClaim{
+		ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example"},
+		Spec:       kube.SecretSpec{Path: "database/creds/app"},
+	}
+}
+
+func Test_issuedLeases(t *testing.T) {
+	claim := &kube.SecretClaim{ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example"}}
+	leases := newIssuedLeases()
+
+	leases.add("pending", claim)
+	leases.add("storing", claim)
+	leases.add("stored", claim)
+	if !leases.store("storing") || !leases.store("stored") {
+		t.Fatal("store() = false, want true for tracked leases")
+	}
+	if !leases.remove("stored") {
+		t.Error("remove() = false, want true for a tracked lease")
+	}
+
+	revoke, storing := leases.drain()
+	if _, ok := revoke["pending"]; !ok || len(revoke) != 1 {
+		t.Errorf("drain() = %v, want only the pending lease", revoke)
+	}
+	if storing != 1 {
+		t.Errorf("drain() storing = %d, want 1", storing)
+	}
+
+	if leases.store("pending") {
+		t.Error("store() of a drained lease = true, want false")
+	}
+	if leases.remove("pending") {
+		t.Error("remove() of a drained lease = true, want false")
+	}
+	if !leases.remove("storing") {
+		t.Error("remove() of a lease being stored = false, want true")
+	}
+	if leases.add("late", claim) {
+		t.Error("add() after drain = true, want false")
+	}
+}
+
+func Test_storeSecret(t *testing.T) {
+	storeErr := errors.New("store failed")
+	tests := []struct {
+		name string
+		// drainBefore drains the leases before the secret is stored.
+		drainBefore bool
+		// drainDuring drains the leases while the secret is being stored.
+		drainDuring bool
+		storeErr    error
+		wantStored  bool
+		wantErr     bool
+		wantRevoked int
+	}{
+		{name: "stored", wantStored: true},
+		{name: "store fails", storeErr: storeErr, wantStored: true, wantErr: true, wantRevoked: 1},
+		{name: "drained before store", drainBefore: true, wantErr: true, wantRevoked: 1},
+		{name: "drained during store", drainDuring: true, wantStored: true},
+		{name: "drained during failing store", drainDuring: true, storeErr: storeErr, wantStored: true, wantErr: true, wantRevoked: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := newFakeVault(t)
+			defer vault.Close()
+			serveCreds(vault)
+			ctrl := newTestController(t, vault)
+			ctrl.mounts = &mountCache{}
+			ctrl.issued = newIssuedLeases()
+			claim := credsClaim()
+
+			secret, err := ctrl.secretForClaim(claim, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.drainBefore {
+				ctrl.RevokeIssued()
+			}
+
+			stored := false
+			_, err = ctrl.storeSecret("example/app", claim, secret, func(secret *v1.Secret) (*v1.Secret, error) {
+				stored = true
+				if tt.drainDuring {
+					ctrl.RevokeIssued()
+				}
+				return secret, tt.storeErr
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("storeSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if stored != tt.wantStored {
+				t.Errorf("storeSecret() stored = %t, want %t", stored, tt.wantStored)
+			}
+
+			ctrl.RevokeIssued()
+			if got := len(vault.requests(revokeLease)); got != tt.wantRevoked {
+				t.Errorf("lease revoked %d times, want %d", got, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func Test_secretForClaim_shutdown(t *testing.T) {
+	vault := newFakeVault(t)
+	defer vault.Close()
+	serveCreds(vault)
+	ctrl := newTestController(t, vault)
+	ctrl.mounts = &mountCache{}
+	ctrl.issued = newIssuedLeases()
+	claim := credsClaim()
+
+	// the shutdown timeout passes while vault is issuing the lease.
+	vault.handle(readCreds, func(w http.ResponseWriter, req *http.Request) {
+		ctrl.RevokeIssued()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"lease_id":"database/creds/app/1","lease_duration":3600,"data":{"username":"app"}}`))
+	})
+
+	if _, err := ctrl.secretForClaim(claim, nil); err != errShuttingDown {
+		t.Errorf("secretForClaim() error = %v, want %v", err, errShuttingDown)
+	}
+	if got := len(vault.requests(revokeLease)); got != 1 {
+		t.Errorf("lease revoked %d times, want 1", got)
+	}
+}
+
+func Test_secretForClaim_tracksLease(t *testing.T) {
+	vault := newFakeVault(t)
+	defer vault.Close()
+	serveCreds(vault)
+	ctrl := newTestController(t, vault)
+	ctrl.mounts = &mountCache{}
+	ctrl.issued = newIssuedLeases()
+	claim := credsClaim()
+
+	// a sync still between reading the secret and storing it when the
+	// shutdown timeout passes.
+	if _, err := ctrl.secretForClaim(claim, nil); err != nil {
+		t.Fatal(err)
+	}
+	ctrl.RevokeIssued()
+	if got := len(vault.requests(revokeLease)); got != 1 {
+		t.Errorf("lease revoked %d times, want 1", got)
+	}
+}
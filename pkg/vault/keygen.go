@@ -0,0 +1,254 @@
+package vault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+const (
+	defaultRSAKeySize   = 2048
+	defaultECDSAKeySize = 256
+	maxRSAKeySize       = 8192
+
+	// PKICSRKey is the certificate request parameter of pki sign endpoints.
+	PKICSRKey = "csr"
+)
+
+// keyFor returns the private key a certificate for the claim is requested
+// for: the key of the existing secret if it may be reused, matches the spec
+// and its certificate, otherwise a new key.
+func keyFor(claim *kube.SecretClaim, existing *v1.Secret) (crypto.Signer, error) {
+	spec := claim.Spec.PrivateKey
+	if err := validatePrivateKeySpec(spec); err != nil {
+		return nil, err
+	}
+
+	if existing != nil && spec.RotationPolicy != kube.RotateAlways {
+		// only a key the stored certificate was issued for is reused, not one
+		// the secret was edited to.
+		if _, key, err := verifyCertificate(existing.Data); err == nil && keyMatches(key, spec) {
+			return key, nil
+		}
+	}
+	return generatePrivateKey(spec)
+}
+
+func validatePrivateKeySpec(spec *kube.PrivateKeySpec) error {
+	switch spec.RotationPolicy {
+	case "", kube.RotateNever, kube.RotateAlways:
+	default:
+		return fmt.Errorf("private key rotation policy must be %s or %s, got %q", kube.RotateNever, kube.RotateAlways, spec.RotationPolicy)
+	}
+
+	switch algorithm(spec) {
+	case kube.PrivateKeyRSA:
+		if size := keySize(spec); size < defaultRSAKeySize || size > maxRSAKeySize {
+			return fmt.Errorf("rsa private key size must be between %d and %d, got %d", defaultRSAKeySize, maxRSAKeySize, size)
+		}
+	case kube.PrivateKeyECDSA:
+		if _, err := curve(keySize(spec)); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("private key algorithm must be %s or %s, got %q", kube.PrivateKeyRSA, kube.PrivateKeyECDSA, spec.Algorithm)
+	}
+	return nil
+}
+
+func algorithm(spec *kube.PrivateKeySpec) string {
+	if spec.Algorithm == "" {
+		return kube.PrivateKeyRSA
+	}
+	return spec.Algorithm
+}
+
+func keySize(spec *kube.PrivateKeySpec) int {
+	if spec.Size != 0 {
+		return spec.Size
+	}
+	if algorithm(spec) == kube.PrivateKeyECDSA {
+		return defaultECDSAKeySize
+	}
+	return defaultRSAKeySize
+}
+
+func curve(size int) (elliptic.Curve, error) {
+	switch size {
+	case 256:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	}
+	return nil, fmt.Errorf("ecdsa private key size must be 256, 384 or 521, got %d", size)
+}
+
+func generatePrivateKey(spec *kube.PrivateKeySpec) (crypto.Signer, error) {
+	if algorithm(spec) == kube.PrivateKeyECDSA {
+		c, err := curve(keySize(spec))
+		if err != nil {
+			return nil, err
+		}
+		return ecdsa.GenerateKey(c, rand.Reader)
+	}
+	return rsa.GenerateKey(rand.Reader, keySize(spec))
+}
+
+// keyMatches returns whether key has the algorithm and size of spec.
+func keyMatches(key crypto.Signer, spec *kube.PrivateKeySpec) bool {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return algorithm(spec) == kube.PrivateKeyRSA && k.N.BitLen() == keySize(spec)
+	case *ecdsa.PrivateKey:
+		return algorithm(spec) == kube.PrivateKeyECDSA && k.Curve.Params().BitSize == keySize(spec)
+	}
+	return false
+}
+
+// encodePrivateKey pem encodes key, rsa keys in pkcs1 and ecdsa keys in sec1
+// form like vault does.
+func encodePrivateKey(key crypto.Signer) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", key)
+}
+
+// parsePrivateKey parses a pem encoded rsa or ecdsa key in pkcs1, sec1 or
+// pkcs8 form.
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem encoded private key found")
+	}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return nil, fmt.Errorf("unsupported pem block %q", block.Type)
+}
+
+// certificateRequest returns a pem encoded certificate request for key with
+// the common name and subject alternative names of the sign parameters.
+func certificateRequest(key crypto.Signer, params map[string]interface{}) ([]byte, error) {
+	template := &x509.CertificateRequest{}
+	if cn, ok := params["common_name"].(string); ok {
+		template.Subject = pkix.Name{CommonName: cn}
+	}
+	template.DNSNames = listParam(params["alt_names"])
+	for _, ip := range listParam(params["ip_sans"]) {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return nil, fmt.Errorf("ip_sans: %q is not an ip address", ip)
+		}
+		template.IPAddresses = append(template.IPAddresses, parsed)
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), nil
+}
+
+// listParam reads a vault list parameter, a comma separated string or a list.
+func listParam(val interface{}) []string {
+	var list []string
+	switch v := val.(type) {
+	case string:
+		for _, element := range strings.Split(v, ",") {
+			if element = strings.TrimSpace(element); element != "" {
+				list = append(list, element)
+			}
+		}
+	case []interface{}:
+		for _, element := range v {
+			if s, ok := element.(string); ok && s != "" {
+				list = append(list, s)
+			}
+		}
+	}
+	return list
+}
+
+// signRequest returns the parameters of a sign request for the claim, its
+// data with a certificate request for key.
+func signRequest(claim *kube.SecretClaim, key crypto.Signer) (map[string]interface{}, error) {
+	csr, err := certificateRequest(key, claim.Spec.Data)
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]interface{}, len(claim.Spec.Data)+1)
+	for k, v := range claim.Spec.Data {
+		params[k] = v
+	}
+	params[PKICSRKey] = string(csr)
+	return params, nil
+}
+
+// signCertificate has vault sign a certificate for a key generated, or
+// reused from existing, by the controller. The key is added to the response
+// as if vault had issued it.
+func signCertificate(logical *vaultapi.Logical, claim *kube.SecretClaim, existing *v1.Secret) (*vaultapi.Secret, error) {
+	if claim.Spec.Type != v1.SecretTypeTLS {
+		return nil, fmt.Errorf("privateKey is only supported on %s claims", v1.SecretTypeTLS)
+	}
+	key, err := keyFor(claim, existing)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := encodePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	params, err := signRequest(claim, key)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	value, err := logical.Write(claim.Spec.Path, params)
+	observeVault("write", start, err)
+	if err != nil || value == nil {
+		return value, err
+	}
+	if value.Data == nil {
+		value.Data = map[string]interface{}{}
+	}
+	value.Data[PKIPrivateKeyKey] = string(keyPEM)
+	return value, nil
+}
@@ -0,0 +1,162 @@
+package vault
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+func Test_keyFor(t *testing.T) {
+	ecKey, err := generatePrivateKey(&kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPEM, err := encodePrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := generatePrivateKey(&kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherPEM, err := encodePrivateKey(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := []byte(testSelfSigned(t, ecKey, time.Now(), time.Now().Add(time.Hour)))
+	existing := &v1.Secret{Data: map[string][]byte{v1.TLSCertKey: cert, v1.TLSPrivateKeyKey: ecPEM}}
+	edited := &v1.Secret{Data: map[string][]byte{v1.TLSCertKey: cert, v1.TLSPrivateKeyKey: otherPEM}}
+
+	tests := []struct {
+		name      string
+		spec      kube.PrivateKeySpec
+		existing  *v1.Secret
+		wantReuse bool
+		wantType  string
+		wantSize  int
+		wantErr   bool
+	}{
+		{name: "rsa by default", spec: kube.PrivateKeySpec{}, wantType: kube.PrivateKeyRSA, wantSize: 2048},
+		{name: "ecdsa curve", spec: kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA, Size: 384}, wantType: kube.PrivateKeyECDSA, wantSize: 384},
+		{name: "reuses the existing key", spec: kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA}, existing: existing, wantReuse: true, wantType: kube.PrivateKeyECDSA, wantSize: 256},
+		{name: "rotates the existing key", spec: kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA, RotationPolicy: kube.RotateAlways}, existing: existing, wantType: kube.PrivateKeyECDSA, wantSize: 256},
+		{name: "replaces a key not matching the spec", spec: kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA, Size: 521}, existing: existing, wantType: kube.PrivateKeyECDSA, wantSize: 521},
+		{name: "replaces a key not matching the certificate", spec: kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA}, existing: edited, wantType: kube.PrivateKeyECDSA, wantSize: 256},
+		{name: "replaces an invalid key", spec: kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA}, existing: &v1.Secret{Data: map[string][]byte{v1.TLSPrivateKeyKey: []byte("key")}}, wantType: kube.PrivateKeyECDSA, wantSize: 256},
+		{name: "unknown algorithm", spec: kube.PrivateKeySpec{Algorithm: "DSA"}, wantErr: true},
+		{name: "short rsa key", spec: kube.PrivateKeySpec{Size: 1024}, wantErr: true},
+		{name: "unknown curve", spec: kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA, Size: 224}, wantErr: true},
+		{name: "unknown rotation policy", spec: kube.PrivateKeySpec{RotationPolicy: "Sometimes"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			claim := &kube.SecretClaim{Spec: kube.SecretSpec{PrivateKey: &spec}}
+			key, err := keyFor(claim, tt.existing)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("keyFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if reused := reflect.DeepEqual(key, ecKey); reused != tt.wantReuse {
+				t.Errorf("keyFor() reused = %t, want %t", reused, tt.wantReuse)
+			}
+			switch k := key.(type) {
+			case *rsa.PrivateKey:
+				if tt.wantType != kube.PrivateKeyRSA || k.N.BitLen() != tt.wantSize {
+					t.Errorf("keyFor() = rsa %d, want %s %d", k.N.BitLen(), tt.wantType, tt.wantSize)
+				}
+			case *ecdsa.PrivateKey:
+				if tt.wantType != kube.PrivateKeyECDSA || k.Curve.Params().BitSize != tt.wantSize {
+					t.Errorf("keyFor() = ecdsa %d, want %s %d", k.Curve.Params().BitSize, tt.wantType, tt.wantSize)
+				}
+			default:
+				t.Errorf("keyFor() = %T", key)
+			}
+
+			encoded, err := encodePrivateKey(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed, err := parsePrivateKey(encoded); err != nil || !reflect.DeepEqual(parsed, key) {
+				t.Errorf("parsePrivateKey(encodePrivateKey()) = %v, %v", parsed, err)
+			}
+		})
+	}
+}
+
+func Test_signRequest(t *testing.T) {
+	key, err := generatePrivateKey(&kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		data    map[string]interface{}
+		wantCN  string
+		wantDNS []string
+		wantIPs int
+		wantErr bool
+	}{
+		{
+			name:    "names from parameters",
+			data:    map[string]interface{}{"common_name": "example.com", "alt_names": "www.example.com, api.example.com", "ip_sans": "10.0.0.1", "ttl": "1h"},
+			wantCN:  "example.com",
+			wantDNS: []string{"www.example.com", "api.example.com"},
+			wantIPs: 1,
+		},
+		{
+			name:    "alt names as a list",
+			data:    map[string]interface{}{"common_name": "example.com", "alt_names": []interface{}{"www.example.com"}},
+			wantCN:  "example.com",
+			wantDNS: []string{"www.example.com"},
+		},
+		{name: "invalid ip", data: map[string]interface{}{"ip_sans": "example.com"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &kube.SecretClaim{Spec: kube.SecretSpec{Data: tt.data}}
+			params, err := signRequest(claim, key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("signRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			for k, v := range tt.data {
+				if !reflect.DeepEqual(params[k], v) {
+					t.Errorf("signRequest()[%q] = %v, want %v", k, params[k], v)
+				}
+			}
+			if _, ok := claim.Spec.Data[PKICSRKey]; ok {
+				t.Errorf("signRequest() modified the claim data")
+			}
+
+			block, _ := pem.Decode([]byte(params[PKICSRKey].(string)))
+			if block == nil || block.Type != "CERTIFICATE REQUEST" {
+				t.Fatalf("signRequest()[csr] is not a pem encoded certificate request")
+			}
+			csr, err := x509.ParseCertificateRequest(block.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := csr.CheckSignature(); err != nil {
+				t.Errorf("csr signature: %v", err)
+			}
+			if !reflect.DeepEqual(csr.PublicKey, key.Public()) {
+				t.Errorf("csr is not for the key")
+			}
+			if csr.Subject.CommonName != tt.wantCN || !reflect.DeepEqual(csr.DNSNames, tt.wantDNS) || len(csr.IPAddresses) != tt.wantIPs {
+				t.Errorf("csr names = %q %v %v, want %q %v and %d ips", csr.Subject.CommonName, csr.DNSNames, csr.IPAddresses, tt.wantCN, tt.wantDNS, tt.wantIPs)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+)
+
+const mountCacheTTL = time.Minute
+
+// kvMount is a kv version 2 mount containing a claim path.
+type kvMount struct {
+	mount string
+	path  string
+}
+
+func (kv *kvMount) dataPath() string {
+	return kv.mount + "data/" + kv.path
+}
+
+func (kv *kvMount) metadataPath() string {
+	return kv.mount + "metadata/" + kv.path
+}
+
+// mountCache caches the kv version of each mount, as read from sys/mounts.
+// Failed reads are cached too, so sys/mounts is read at most once per ttl.
+type mountCache struct {
+	mu       sync.Mutex
+	versions map[string]int
+	// err is the error reading sys/mounts while no read has succeeded yet.
+	err     error
+	updated time.Time
+}
+
+// kvMountFor returns the kv version 2 mount for path, or nil if the path is
+// not on a kv version 2 mount. If the mounts are unknown because sys/mounts
+// can't be read, it returns nil and the error.
+func (ctrl *controller) kvMountFor(path string) (*kvMount, error) {
+	ctrl.mounts.mu.Lock()
+	defer ctrl.mounts.mu.Unlock()
+
+	if ctrl.mounts.updated.IsZero() || timeNow().Sub(ctrl.mounts.updated) > mountCacheTTL {
+		versions, err := readMountVersions(ctrl.token.Client())
+		if err != nil {
+			log.Printf("vault-controller: failed to read sys/mounts: %s", err.Error())
+			if ctrl.mounts.versions == nil {
+				ctrl.mounts.err = fmt.Errorf("failed to read sys/mounts: %s", err.Error())
+			}
+		} else {
+			ctrl.mounts.versions = versions
+			ctrl.mounts.err = nil
+		}
+		ctrl.mounts.updated = timeNow()
+	}
+
+	return kvMountFromVersions(ctrl.mounts.versions, path), ctrl.mounts.err
+}
+
+func kvMountFromVersions(versions map[string]int, path string) *kvMount {
+	var match string
+	for mount := range versions {
+		if strings.HasPrefix(path, mount) && len(mount) > len(match) {
+			match = mount
+		}
+	}
+	if match == "" || versions[match] != 2 {
+		return nil
+	}
+	return &kvMount{
+		mount: match,
+		path:  strings.TrimPrefix(path, match),
+	}
+}
+
+// readMountVersions returns the kv version of every mount, keyed by mount
+// path with a trailing slash. Mounts other than kv are version 0.
+func readMountVersions(client *vaultapi.Client) (map[string]int, error) {
+	resp, err := client.RawRequest(client.NewRequest("GET", "/v1/sys/mounts"))
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result map[string]interface{}
+	if err := resp.DecodeJSON(&result); err != nil {
+		return nil, err
+	}
+	mounts := result
+	if data, ok := result["data"].(map[string]interface{}); ok {
+		mounts = data
+	}
+
+	versions := map[string]int{}
+	for path, raw := range mounts {
+		mount, ok := raw.(map[string]interface{})
+		if !ok || !strings.HasSuffix(path, "/") {
+			continue
+		}
+
+		versions[path] = 0
+		if typ, _ := mount["type"].(string); typ != "kv" && typ != "generic" {
+			continue
+		}
+		versions[path] = 1
+		if options, ok := mount["options"].(map[string]interface{}); ok {
+			if version, _ := options["version"].(string); version == "2" {
+				versions[path] = 2
+			}
+		}
+	}
+	return versions, nil
+}
+
+// readKV reads a kv version 2 secret, returning the secret with its data
+// unwrapped and the version read.
+func readKV(client *vaultapi.Client, kv *kvMount, version int) (*vaultapi.Secret, int, error) {
+	req := client.NewRequest("GET", "/v1/"+kv.dataPath())
+	if version > 0 {
+		req.Params.Set("version", strconv.Itoa(version))
+	}
+
+	resp, err := client.RawRequest(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	secret, err := vaultapi.ParseSecret(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+	resolved, err := unwrapKV(secret)
+	if err != nil {
+		return nil, 0, err
+	}
+	return secret, resolved, nil
+}
+
+// unwrapKV replaces the data of a kv version 2 response with the nested
+// secret data, returning the version from its metadata.
+func unwrapKV(secret *vaultapi.Secret) (int, error) {
+	if secret == nil || secret.Data == nil {
+		return 0, fmt.Errorf("kv response has no data")
+	}
+
+	var version int
+	if metadata, ok := secret.Data["metadata"].(map[string]interface{}); ok {
+		v, err := secretInt(metadata["version"])
+		if err != nil {
+			return 0, fmt.Errorf("kv response has no version: %s", err.Error())
+		}
+		version = int(v)
+	}
+
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return 0, fmt.Errorf("kv version %d has been deleted or destroyed", version)
+	}
+	secret.Data = data
+	return version, nil
+}
+
+// currentKVVersion returns the current version of a kv version 2 secret.
+func currentKVVersion(client *vaultapi.Client, kv *kvMount) (int, error) {
+	start := time.Now()
+	secret, err := client.Logical().Read(kv.metadataPath())
+	observeVault("read", start, err)
+	if err != nil {
+		return 0, err
+	}
+	if secret == nil || secret.Data == nil {
+		return 0, fmt.Errorf("no metadata found for %s", kv.metadataPath())
+	}
+	version, err := secretInt(secret.Data["current_version"])
+	return int(version), err
+}
+
+// kvVersionChanged returns true if the kv version recorded on the secret is
+// not the version the claim should have.
+func (ctrl *controller) kvVersionChanged(claim *kube.SecretClaim, kv *kvMount, recorded string) (bool, error) {
+	want := claim.Spec.Version
+	if want == 0 {
+		vclient, err := ctrl.clientForClaim(claim)
+		if err != nil {
+			return false, err
+		}
+		want, err = currentKVVersion(vclient, kv)
+		if err != nil {
+			return false, err
+		}
+	}
+	return recorded != strconv.Itoa(want), nil
+}
@@ -0,0 +1,176 @@
+package vault
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+func Test_kvMountFromVersions(t *testing.T) {
+	versions := map[string]int{
+		"secret/":      1,
+		"kv/":          2,
+		"kv/nested/":   1,
+		"pki/":         0,
+		"team/shared/": 2,
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want *kvMount
+	}{
+		{
+			name: "kv version 1 mount",
+			path: "secret/example",
+			want: nil,
+		},
+		{
+			name: "kv version 2 mount",
+			path: "kv/example/key",
+			want: &kvMount{mount: "kv/", path: "example/key"},
+		},
+		{
+			name: "longest mount wins",
+			path: "kv/nested/key",
+			want: nil,
+		},
+		{
+			name: "multi segment mount",
+			path: "team/shared/key",
+			want: &kvMount{mount: "team/shared/", path: "key"},
+		},
+		{
+			name: "non kv mount",
+			path: "pki/issue/example",
+			want: nil,
+		},
+		{
+			name: "unknown mount",
+			path: "kvx/example",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := kvMountFromVersions(versions, tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("kvMountFromVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_unwrapKV(t *testing.T) {
+	tests := []struct {
+		name        string
+		secret      *vaultapi.Secret
+		wantData    map[string]interface{}
+		wantVersion int
+		wantErr     bool
+	}{
+		{
+			name: "nested data is unwrapped",
+			secret: &vaultapi.Secret{
+				Data: map[string]interface{}{
+					"data": map[string]interface{}{
+						"username": "user",
+					},
+					"metadata": map[string]interface{}{
+						"version": json.Number("3"),
+					},
+				},
+			},
+			wantData: map[string]interface{}{
+				"username": "user",
+			},
+			wantVersion: 3,
+		},
+		{
+			name: "deleted version has no data",
+			secret: &vaultapi.Secret{
+				Data: map[string]interface{}{
+					"data": nil,
+					"metadata": map[string]interface{}{
+						"version": json.Number("2"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "empty response",
+			secret:  &vaultapi.Secret{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := unwrapKV(tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unwrapKV() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if version != tt.wantVersion {
+				t.Errorf("unwrapKV() version = %d, want %d", version, tt.wantVersion)
+			}
+			if !reflect.DeepEqual(tt.secret.Data, tt.wantData) {
+				t.Errorf("unwrapKV() data = %v, want %v", tt.secret.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+func Test_kvMountFor(t *testing.T) {
+	now := timeNow()
+	defer func(previous func() time.Time) { timeNow = previous }(timeNow)
+	timeNow = func() time.Time { return now }
+
+	vault := newFakeVault(t)
+	defer vault.Close()
+	vault.respond(lookupSelf, http.StatusOK, lookupResponse(0, 0))
+	vault.respond(sysMounts, http.StatusForbidden, `{"errors":["permission denied"]}`)
+	token, err := NewToken(vault.config(), &TokenConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl := &controller{token: token, mounts: &mountCache{}}
+
+	steps := []struct {
+		name     string
+		after    time.Duration
+		status   int
+		want     *kvMount
+		wantErr  bool
+		requests int
+	}{
+		{name: "sys/mounts denied", status: http.StatusForbidden, wantErr: true, requests: 1},
+		{name: "failure is cached", after: mountCacheTTL / 2, status: http.StatusForbidden, wantErr: true, requests: 1},
+		{name: "read again after ttl", after: mountCacheTTL, status: http.StatusOK, want: &kvMount{mount: "secret/", path: "example"}, requests: 2},
+		{name: "failure keeps known mounts", after: 2 * mountCacheTTL, status: http.StatusForbidden, want: &kvMount{mount: "secret/", path: "example"}, requests: 3},
+	}
+	for _, step := range steps {
+		now = now.Add(step.after)
+		if step.status == http.StatusOK {
+			vault.respond(sysMounts, http.StatusOK, `{"secret/":{"type":"kv","options":{"version":"2"}}}`)
+		} else {
+			vault.respond(sysMounts, step.status, `{"errors":["permission denied"]}`)
+		}
+
+		got, err := ctrl.kvMountFor("secret/example")
+		if (err != nil) != step.wantErr {
+			t.Errorf("%s: kvMountFor() error = %v, wantErr %v", step.name, err, step.wantErr)
+		}
+		if !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: kvMountFor() = %+v, want %+v", step.name, got, step.want)
+		}
+		if requests := len(vault.requests(sysMounts)); requests != step.requests {
+			t.Errorf("%s: kvMountFor() read sys/mounts %d times, want %d", step.name, requests, step.requests)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package vault
+
+import (
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/metrics"
+)
+
+var (
+	vaultRequests = metrics.DefaultRegistry.NewCounterVec(
+		"kube_vault_controller_vault_requests_total",
+		"Vault requests by operation and result.",
+		"operation", "result",
+	)
+	vaultRequestDuration = metrics.DefaultRegistry.NewHistogramVec(
+		"kube_vault_controller_vault_request_duration_seconds",
+		"Latency of vault requests by operation and result.",
+		metrics.DefaultBuckets,
+		"operation", "result",
+	)
+)
+
+// observeVault records a vault request that started at start. Operations are
+// read, write, renew, revoke, login and renew_token.
+func observeVault(operation string, start time.Time, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	vaultRequests.Inc(operation, result)
+	vaultRequestDuration.Observe(time.Since(start).Seconds(), operation, result)
+}
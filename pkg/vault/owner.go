@@ -0,0 +1,146 @@
+package vault
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	kerrors "k8s.io/client-go/pkg/api/errors"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+// Secrets generated for claims are labeled as managed by the controller.
+const (
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "kube-vault-controller"
+)
+
+// BackupOfKey annotates the backup of an adopted secret with its name.
+const BackupOfKey = "vaultproject.io/backup-of"
+
+// backupSuffix is appended to the name of an adopted secret for its backup.
+const backupSuffix = "-backup"
+
+// secretObjectMeta returns the metadata of the secret generated for a claim,
+// owned by the claim and labeled as managed by the controller.
+func secretObjectMeta(claim *kube.SecretClaim, secret *vaultapi.Secret) v1.ObjectMeta {
+	return v1.ObjectMeta{
+		Name:      claim.Name,
+		Namespace: claim.Namespace,
+
+		Labels: map[string]string{
+			ManagedByLabel: ManagedByValue,
+		},
+		Annotations:     buildSecretAnnotations(secret, claim),
+		OwnerReferences: ownerReferences(claim),
+	}
+}
+
+func ownerReferences(claim *kube.SecretClaim) []v1.OwnerReference {
+	if claim.UID == "" {
+		return nil
+	}
+
+	controller := true
+	return []v1.OwnerReference{{
+		APIVersion: kube.GroupVersion.String(),
+		Kind:       "SecretClaim",
+		Name:       claim.Name,
+		UID:        claim.UID,
+		Controller: &controller,
+	}}
+}
+
+// IsManaged returns whether the secret was generated by the controller.
+func IsManaged(secret *v1.Secret) bool {
+	return secret.Labels[ManagedByLabel] == ManagedByValue
+}
+
+// ClaimOwner returns the owner reference of the secret to a secret claim, or
+// nil if it has none.
+func ClaimOwner(secret *v1.Secret) *v1.OwnerReference {
+	for i, owner := range secret.OwnerReferences {
+		if owner.APIVersion == kube.GroupVersion.String() && owner.Kind == "SecretClaim" {
+			return &secret.OwnerReferences[i]
+		}
+	}
+	return nil
+}
+
+// ownedBy returns whether the controller may write or delete the secret for
+// the claim. A secret with an owner reference to a secret claim is owned by
+// the claim with that uid only, so a deleted claim never touches the secret
+// of the claim that replaced it. Otherwise it is owned if labeled as managed,
+// or if it has a lease id annotation from before secrets were labeled and no
+// owner references. Claims without a uid, the stand-ins for orphaned
+// secrets, only own secrets with an owner reference to a claim of their
+// name, as anyone who can create a secret can label it as managed.
+func ownedBy(secret *v1.Secret, claim *kube.SecretClaim) bool {
+	if owner := ClaimOwner(secret); owner != nil {
+		return owner.Name == claim.Name && (claim.UID == "" || owner.UID == claim.UID)
+	}
+	if claim.UID == "" {
+		return false
+	}
+	if IsManaged(secret) {
+		return true
+	}
+	if len(secret.OwnerReferences) > 0 {
+		return false
+	}
+	_, legacy := secret.Annotations[LeaseIDKey]
+	return legacy
+}
+
+// adoptSecret backs up an existing secret the controller doesn't own, so it
+// can be overwritten. It returns a conflict unless the claim sets
+// adoptExisting.
+func (ctrl *controller) adoptSecret(key string, claim *kube.SecretClaim, existing *v1.Secret) error {
+	if !claim.Spec.AdoptExisting {
+		return &claimError{
+			reason: ReasonConflict,
+			err:    fmt.Errorf("secret %s exists and is not managed by the controller, set adoptExisting to take it over", key),
+		}
+	}
+	if existing.Type == v1.SecretTypeServiceAccountToken {
+		return &claimError{
+			reason: ReasonConflict,
+			err:    fmt.Errorf("secret %s is a service account token and can't be adopted", key),
+		}
+	}
+
+	backup := &v1.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        existing.Name + backupSuffix,
+			Namespace:   existing.Namespace,
+			Labels:      existing.Labels,
+			Annotations: map[string]string{},
+		},
+		Type: existing.Type,
+		Data: existing.Data,
+	}
+	for k, v := range existing.Annotations {
+		backup.Annotations[k] = v
+	}
+	backup.Annotations[BackupOfKey] = existing.Name
+
+	_, err := ctrl.kclient.Core().Secrets(existing.Namespace).Create(backup)
+	if kerrors.IsAlreadyExists(err) {
+		// a previous attempt may have backed it up before failing to write.
+		previous, getErr := ctrl.kclient.Core().Secrets(existing.Namespace).Get(backup.Name)
+		if getErr == nil && previous.Annotations[BackupOfKey] == existing.Name && reflect.DeepEqual(previous.Data, existing.Data) {
+			return nil
+		}
+		return &claimError{
+			reason: ReasonConflict,
+			err:    fmt.Errorf("can't back up secret %s, %s already exists", key, backup.Name),
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up secret %s: %s", key, err.Error())
+	}
+	log.Printf("vault-controller: %s: backed up existing secret to %s", key, backup.Name)
+	return nil
+}
@@ -0,0 +1,176 @@
+package vault
+
+import (
+	"reflect"
+	"testing"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"k8s.io/client-go/pkg/api"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+func Test_secretObjectMeta(t *testing.T) {
+	controller := true
+	tests := []struct {
+		name  string
+		claim *kube.SecretClaim
+		want  []v1.OwnerReference
+	}{
+		{
+			name: "owned by claim",
+			claim: &kube.SecretClaim{
+				ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example", UID: "1234"},
+			},
+			want: []v1.OwnerReference{{
+				APIVersion: "vaultproject.io/v1",
+				Kind:       "SecretClaim",
+				Name:       "app",
+				UID:        "1234",
+				Controller: &controller,
+			}},
+		},
+		{
+			name: "claim without uid",
+			claim: &kube.SecretClaim{
+				ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example"},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := secretObjectMeta(tt.claim, &vaultapi.Secret{})
+			if got.Name != tt.claim.Name || got.Namespace != tt.claim.Namespace {
+				t.Errorf("secretObjectMeta() = %s/%s, want %s/%s", got.Namespace, got.Name, tt.claim.Namespace, tt.claim.Name)
+			}
+			if !IsManaged(&v1.Secret{ObjectMeta: got}) {
+				t.Errorf("secretObjectMeta() labels = %v, want managed", got.Labels)
+			}
+			if !reflect.DeepEqual(got.OwnerReferences, tt.want) {
+				t.Errorf("secretObjectMeta() owner references = %+v, want %+v", got.OwnerReferences, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ownedBy(t *testing.T) {
+	claim := &kube.SecretClaim{ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example", UID: "1234"}}
+	tests := []struct {
+		name   string
+		secret *v1.Secret
+		want   bool
+	}{
+		{
+			name: "managed label",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				Labels: map[string]string{ManagedByLabel: ManagedByValue},
+			}},
+			want: true,
+		},
+		{
+			name: "owner reference to the claim",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				OwnerReferences: []v1.OwnerReference{{APIVersion: "vaultproject.io/v1", Kind: "SecretClaim", Name: "app", UID: "1234"}},
+			}},
+			want: true,
+		},
+		{
+			name: "owner reference to a replaced claim",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				OwnerReferences: []v1.OwnerReference{{APIVersion: "vaultproject.io/v1", Kind: "SecretClaim", Name: "app", UID: "5678"}},
+			}},
+			want: false,
+		},
+		{
+			name: "managed label and owner reference to a replaced claim",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				Labels:          map[string]string{ManagedByLabel: ManagedByValue},
+				OwnerReferences: []v1.OwnerReference{{APIVersion: "vaultproject.io/v1", Kind: "SecretClaim", Name: "app", UID: "5678"}},
+			}},
+			want: false,
+		},
+		{
+			name: "owner reference to another claim",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				OwnerReferences: []v1.OwnerReference{{APIVersion: "vaultproject.io/v1", Kind: "SecretClaim", Name: "other", UID: "1234"}},
+			}},
+			want: false,
+		},
+		{
+			name: "lease annotation from before secrets were labeled",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				Annotations: map[string]string{LeaseIDKey: ""},
+			}},
+			want: true,
+		},
+		{
+			name: "lease annotation and owned by something else",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				Annotations:     map[string]string{LeaseIDKey: ""},
+				OwnerReferences: []v1.OwnerReference{{APIVersion: "v1", Kind: "ServiceAccount", Name: "app"}},
+			}},
+			want: false,
+		},
+		{
+			name: "owned by something else",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				OwnerReferences: []v1.OwnerReference{{APIVersion: "v1", Kind: "ServiceAccount", Name: "app"}},
+			}},
+			want: false,
+		},
+		{
+			name:   "hand made secret",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"note": "by hand"}}},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ownedBy(tt.secret, claim); got != tt.want {
+				t.Errorf("ownedBy() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ownedBy_orphan(t *testing.T) {
+	// the stand-in claim of an orphaned secret has no uid.
+	orphan := &kube.SecretClaim{ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "example"}}
+	tests := []struct {
+		name   string
+		secret *v1.Secret
+		want   bool
+	}{
+		{
+			name: "owner reference to a claim",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				Labels:          map[string]string{ManagedByLabel: ManagedByValue},
+				OwnerReferences: []v1.OwnerReference{{APIVersion: "vaultproject.io/v1", Kind: "SecretClaim", Name: "app", UID: "5678"}},
+			}},
+			want: true,
+		},
+		{
+			name: "managed label only",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				Labels:      map[string]string{ManagedByLabel: ManagedByValue},
+				Annotations: map[string]string{LeaseIDKey: "database/creds/app/1"},
+			}},
+			want: false,
+		},
+		{
+			name: "lease annotation only",
+			secret: &v1.Secret{ObjectMeta: v1.ObjectMeta{
+				Annotations: map[string]string{LeaseIDKey: "database/creds/app/1"},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ownedBy(tt.secret, orphan); got != tt.want {
+				t.Errorf("ownedBy() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// canonicalPath returns the path if vault resolves it to itself, so checks on
+// the path hold for what vault reads. Rather than resolving paths like vault
+// does, paths vault would resolve differently are rejected: empty, "." and
+// ".." segments, which vault cleans, percent encodings, which vault decodes,
+// and characters that end or escape the request path.
+func canonicalPath(path string) (string, error) {
+	if path == "" {
+		return "", errors.New("path is empty")
+	}
+	for _, r := range path {
+		switch {
+		case r < 0x20 || r == 0x7f:
+			return "", errors.New("path contains a control character")
+		case r == '%':
+			return "", errors.New("path contains a percent encoding")
+		case r == '?' || r == '#' || r == '\\':
+			return "", fmt.Errorf("path contains %q", r)
+		}
+	}
+	for _, segment := range strings.Split(path, "/") {
+		switch segment {
+		case "":
+			return "", errors.New("path contains an empty segment")
+		case ".", "..":
+			return "", fmt.Errorf("path contains a %q segment", segment)
+		}
+	}
+	return path, nil
+}
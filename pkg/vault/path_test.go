@@ -0,0 +1,126 @@
+package vault
+
+import (
+	"math/rand"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+)
+
+func Test_canonicalPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "canonical", path: "secret/cluster-name/a/key"},
+		{name: "empty", path: "", wantErr: true},
+		{name: "parent segment", path: "secret/cluster-name/a/../b/key", wantErr: true},
+		{name: "current segment", path: "secret/./cluster-name/b/key", wantErr: true},
+		{name: "doubled slash", path: "secret//cluster-name/b/key", wantErr: true},
+		{name: "leading slash", path: "/secret/cluster-name/b/key", wantErr: true},
+		{name: "trailing slash", path: "secret/cluster-name/a/", wantErr: true},
+		{name: "encoded parent segment", path: "secret/cluster-name/a/%2e%2e/b/key", wantErr: true},
+		{name: "encoded slash", path: "secret%2Fcluster-name/b/key", wantErr: true},
+		{name: "query", path: "secret/cluster-name/a/key?version=1", wantErr: true},
+		{name: "fragment", path: "secret/cluster-name/a/key#b", wantErr: true},
+		{name: "backslash", path: `secret/cluster-name/a/..\b/key`, wantErr: true},
+		{name: "control character", path: "secret/cluster-name/a/\nkey", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canonicalPath(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("canonicalPath() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.path {
+				t.Errorf("canonicalPath() = %q, want %q", got, tt.path)
+			}
+		})
+	}
+}
+
+// claimPath is a path built from segments likely to confuse the namespace
+// check.
+type claimPath string
+
+var claimPathSegments = []string{
+	"secret", "cluster-name", "cluster-name_", "a", "b", "ab", "key", "",
+	".", "..", "%2e", "%2e%2e", "%2E%2E", "%2f", "%2F", "%252e%252e",
+	"a?", "#", `\`, `..\b`, "a%00", "\x00", "\t", " ", "a/..",
+}
+
+func (claimPath) Generate(r *rand.Rand, size int) reflect.Value {
+	n := 1 + r.Intn(8)
+	segments := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		segments = append(segments, claimPathSegments[r.Intn(len(claimPathSegments))])
+	}
+	p := strings.Join(segments, "/")
+	if r.Intn(4) == 0 {
+		p = "/" + p
+	}
+	if r.Intn(4) == 0 {
+		p += "/"
+	}
+	return reflect.ValueOf(claimPath(p))
+}
+
+// vaultResolve resolves a path the ways vault or a proxy in front of it might:
+// decoding percent encodings, dropping a query or fragment, treating
+// backslashes as separators and cleaning the result.
+func vaultResolve(p string) string {
+	if decoded, err := url.PathUnescape(p); err == nil {
+		p = decoded
+	}
+	if i := strings.IndexAny(p, "?#"); i >= 0 {
+		p = p[:i]
+	}
+	p = strings.Replace(p, `\`, "/", -1)
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
+
+func Test_namespaceIsolation(t *testing.T) {
+	prefixes := []string{"secret/cluster-name/", "secret/cluster-name_"}
+	namespace := "a"
+
+	for _, prefix := range prefixes {
+		property := func(p claimPath) bool {
+			canonical, err := canonicalPath(string(p))
+			if err != nil || !pathAllowed(canonical, prefix, namespace) {
+				return true
+			}
+
+			resolved := vaultResolve(string(p))
+			if !strings.HasPrefix(resolved, prefix) {
+				return true
+			}
+			return strings.HasPrefix(resolved, prefix+namespace+"/")
+		}
+		if err := quick.Check(property, &quick.Config{MaxCount: 20000}); err != nil {
+			t.Errorf("prefix %q: a claim in namespace %q reached another namespace: %v", prefix, namespace, err)
+		}
+	}
+}
+
+func Test_namespaceIsolation_rawPathAllowed(t *testing.T) {
+	// without canonicalization the namespace check can be bypassed, which
+	// shows the property test exercises the hole.
+	bypasses := []string{
+		"secret/cluster-name/a/../b/key",
+		"secret//cluster-name/b/key",
+		"secret/cluster-name/a/%2e%2e/b/key",
+	}
+	for _, p := range bypasses {
+		resolved := vaultResolve(p)
+		if !pathAllowed(p, "secret/cluster-name/", "a") || strings.HasPrefix(resolved, "secret/cluster-name/a/") {
+			t.Errorf("%q is not a bypass of pathAllowed", p)
+		}
+		if _, err := canonicalPath(p); err == nil {
+			t.Errorf("canonicalPath(%q) did not reject a bypass", p)
+		}
+	}
+}
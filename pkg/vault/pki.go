@@ -0,0 +1,218 @@
+package vault
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+const (
+	PKIIssuingCAKey = "issuing_ca"
+	PKICAChainKey   = "ca_chain"
+
+	// TLSCAKey is the key of the issuing CA in kubernetes.io/tls secrets.
+	TLSCAKey = "ca.crt"
+
+	CertificateSerialKey     = "vaultproject.io/certificate-serial"
+	CertificateExpirationKey = "vaultproject.io/certificate-expiration"
+)
+
+// certBundle is a certificate issued by a vault pki mount.
+type certBundle struct {
+	certificate string
+	privateKey  string
+	issuingCA   string
+	caChain     []string
+}
+
+// parseCertBundle reads a certificate from the response data of a pki mount.
+// The certificate and private key are required, the issuing CA and chain are
+// optional.
+func parseCertBundle(data map[string]interface{}) (*certBundle, error) {
+	bundle := &certBundle{}
+	var err error
+	if bundle.certificate, err = pkiString(data, PKICertificateKey, true); err != nil {
+		return nil, err
+	}
+	if bundle.privateKey, err = pkiString(data, PKIPrivateKeyKey, true); err != nil {
+		return nil, err
+	}
+	if bundle.issuingCA, err = pkiString(data, PKIIssuingCAKey, false); err != nil {
+		return nil, err
+	}
+
+	switch chain := data[PKICAChainKey].(type) {
+	case nil:
+	case []interface{}:
+		for i, cert := range chain {
+			s, ok := cert.(string)
+			if !ok {
+				return nil, fmt.Errorf("pki response %s[%d] is a %T, not a string", PKICAChainKey, i, cert)
+			}
+			bundle.caChain = append(bundle.caChain, s)
+		}
+	default:
+		return nil, fmt.Errorf("pki response %s is a %T, not a list", PKICAChainKey, chain)
+	}
+	return bundle, nil
+}
+
+func pkiString(data map[string]interface{}, key string, required bool) (string, error) {
+	val, ok := data[key]
+	if !ok || val == nil {
+		if required {
+			return "", fmt.Errorf("pki response has no %s", key)
+		}
+		return "", nil
+	}
+	s, ok := val.(string)
+	if !ok {
+		return "", fmt.Errorf("pki response %s is a %T, not a string", key, val)
+	}
+	if required && strings.TrimSpace(s) == "" {
+		return "", fmt.Errorf("pki response %s is empty", key)
+	}
+	return s, nil
+}
+
+// tlsData returns the data of a kubernetes.io/tls secret for bundle. With
+// fullChain the certificate is followed by the CA chain, or the issuing CA if
+// there is no chain.
+func tlsData(bundle *certBundle, fullChain bool) map[string][]byte {
+	cert := bundle.certificate
+	if fullChain {
+		chain := bundle.caChain
+		if len(chain) == 0 && bundle.issuingCA != "" {
+			chain = []string{bundle.issuingCA}
+		}
+		for _, ca := range chain {
+			if strings.TrimSpace(ca) == strings.TrimSpace(bundle.certificate) {
+				continue
+			}
+			cert = strings.TrimRight(cert, "\n") + "\n" + ca
+		}
+	}
+
+	data := map[string][]byte{
+		v1.TLSCertKey:       []byte(cert),
+		v1.TLSPrivateKeyKey: []byte(bundle.privateKey),
+	}
+	if bundle.issuingCA != "" {
+		data[TLSCAKey] = []byte(bundle.issuingCA)
+	}
+	return data
+}
+
+// parseCertificate parses the first certificate of pem encoded data, the leaf
+// of a chain.
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no pem encoded certificate found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
+
+// certificateAnnotations returns the serial number and expiration of the
+// certificate of a kubernetes.io/tls secret.
+func certificateAnnotations(data map[string][]byte) (map[string]string, error) {
+	cert, err := parseCertificate(data[v1.TLSCertKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %s", err.Error())
+	}
+	return map[string]string{
+		CertificateSerialKey:     formatSerial(cert.SerialNumber.Bytes()),
+		CertificateExpirationKey: strconv.FormatInt(cert.NotAfter.Unix(), 10),
+	}, nil
+}
+
+// formatSerial formats a serial number as colon separated hex bytes, like
+// vault does.
+func formatSerial(serial []byte) string {
+	buf := &bytes.Buffer{}
+	for i, b := range serial {
+		if i > 0 {
+			buf.WriteByte(':')
+		}
+		fmt.Fprintf(buf, "%02x", b)
+	}
+	return buf.String()
+}
+
+// copyCertificateAnnotations copies the certificate annotations of existing
+// to annotations, for updates that keep the data of the secret.
+func copyCertificateAnnotations(annotations map[string]string, existing *v1.Secret) {
+	for _, key := range []string{CertificateSerialKey, CertificateExpirationKey} {
+		if val, ok := existing.Annotations[key]; ok {
+			annotations[key] = val
+		}
+	}
+}
+
+// verifyCertificate parses the certificate and private key of a
+// kubernetes.io/tls secret and checks the certificate is for the key.
+func verifyCertificate(data map[string][]byte) (*x509.Certificate, crypto.Signer, error) {
+	cert, err := parseCertificate(data[v1.TLSCertKey])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate: %s", err.Error())
+	}
+	key, err := parsePrivateKey(data[v1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid private key: %s", err.Error())
+	}
+
+	certPublic, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid certificate public key: %s", err.Error())
+	}
+	keyPublic, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid private key: %s", err.Error())
+	}
+	if !bytes.Equal(certPublic, keyPublic) {
+		return nil, nil, errors.New("certificate does not match the private key")
+	}
+	return cert, key, nil
+}
+
+// timeUntilRotation returns how long until the certificate of a
+// kubernetes.io/tls secret should be rotated, renewBeforePercent of its
+// lifetime or renew seconds before it expires. If renew is longer than the
+// lifetime, it is rotated a third of its lifetime before it expires.
+func timeUntilRotation(key string, claim *kube.SecretClaim, existing *v1.Secret) (time.Duration, error) {
+	cert, _, err := verifyCertificate(existing.Data)
+	if err != nil {
+		return 0, fmt.Errorf("needs update, %s", err.Error())
+	}
+
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	var buffer time.Duration
+	if percent := claim.Spec.RenewBeforePercent; percent > 0 && percent < 100 {
+		buffer = lifetime / 100 * time.Duration(percent)
+	} else {
+		buffer = time.Duration(claim.Spec.Renew) * time.Second
+		if buffer == 0 {
+			buffer = time.Hour
+		}
+		if buffer >= lifetime {
+			log.Printf("vault-controller: %s: renew is longer than the certificate lifetime %s, rotating a third of it before expiry", key, lifetime)
+			buffer = lifetime / 3
+		}
+	}
+	return cert.NotAfter.Add(-buffer).Sub(timeNow()), nil
+}
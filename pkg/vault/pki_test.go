@@ -0,0 +1,247 @@
+package vault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+// testCertificate returns a pem encoded certificate for name with its key,
+// signed by parent or self-signed if parent is nil.
+func testCertificate(t *testing.T, name string, serial int64, notAfter time.Time, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             notAfter.Add(-time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  parent == nil,
+		BasicConstraintsValid: true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// testSelfSigned returns a pem encoded self-signed certificate for key.
+func testSelfSigned(t *testing.T, key crypto.Signer, notBefore, notAfter time.Time) string {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func Test_dataForSecret_tls(t *testing.T) {
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	ca, caKey, caPEM := testCertificate(t, "ca", 1, notAfter, nil, nil)
+	_, _, leafPEM := testCertificate(t, "example.com", 0x1234ab, notAfter, ca, caKey)
+
+	tests := []struct {
+		name      string
+		data      map[string]interface{}
+		fullChain bool
+		want      map[string]string
+		wantErr   string
+	}{
+		{
+			name: "certificate and issuing ca",
+			data: map[string]interface{}{"certificate": leafPEM, "private_key": "key", "issuing_ca": caPEM},
+			want: map[string]string{"tls.crt": leafPEM, "tls.key": "key", "ca.crt": caPEM},
+		},
+		{
+			name:      "full chain from ca_chain",
+			data:      map[string]interface{}{"certificate": leafPEM, "private_key": "key", "issuing_ca": caPEM, "ca_chain": []interface{}{caPEM}},
+			fullChain: true,
+			want:      map[string]string{"tls.crt": leafPEM + caPEM, "tls.key": "key", "ca.crt": caPEM},
+		},
+		{
+			name:      "full chain from issuing ca",
+			data:      map[string]interface{}{"certificate": leafPEM, "private_key": "key", "issuing_ca": caPEM},
+			fullChain: true,
+			want:      map[string]string{"tls.crt": leafPEM + caPEM, "tls.key": "key", "ca.crt": caPEM},
+		},
+		{
+			name:      "full chain without ca",
+			data:      map[string]interface{}{"certificate": leafPEM, "private_key": "key"},
+			fullChain: true,
+			want:      map[string]string{"tls.crt": leafPEM, "tls.key": "key"},
+		},
+		{
+			name:    "missing certificate",
+			data:    map[string]interface{}{"private_key": "key"},
+			wantErr: "pki response has no certificate",
+		},
+		{
+			name:    "missing private key",
+			data:    map[string]interface{}{"certificate": leafPEM},
+			wantErr: "pki response has no private_key",
+		},
+		{
+			name:    "certificate not a string",
+			data:    map[string]interface{}{"certificate": 5, "private_key": "key"},
+			wantErr: "pki response certificate is a int, not a string",
+		},
+		{
+			name:    "ca chain not a list",
+			data:    map[string]interface{}{"certificate": leafPEM, "private_key": "key", "ca_chain": caPEM},
+			wantErr: "pki response ca_chain is a string, not a list",
+		},
+		{
+			name:    "ca chain of non strings",
+			data:    map[string]interface{}{"certificate": leafPEM, "private_key": "key", "ca_chain": []interface{}{1}},
+			wantErr: "pki response ca_chain[0] is a int, not a string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claim := &kube.SecretClaim{Spec: kube.SecretSpec{Type: v1.SecretTypeTLS, FullChain: tt.fullChain}}
+			got, err := dataForSecret(claim, &vaultapi.Secret{Data: tt.data})
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("dataForSecret() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dataForSecret() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("dataForSecret() has keys %v, want %v", got, tt.want)
+			}
+			for key, want := range tt.want {
+				if string(got[key]) != want {
+					t.Errorf("dataForSecret()[%q] = %q, want %q", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_certificateAnnotations(t *testing.T) {
+	notAfter := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	ca, caKey, caPEM := testCertificate(t, "ca", 1, notAfter.Add(time.Hour), nil, nil)
+	_, _, leafPEM := testCertificate(t, "example.com", 0x1234ab, notAfter, ca, caKey)
+
+	tests := []struct {
+		name    string
+		cert    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "leaf",
+			cert: leafPEM,
+			want: map[string]string{CertificateSerialKey: "12:34:ab", CertificateExpirationKey: strconv.FormatInt(notAfter.Unix(), 10)},
+		},
+		{
+			name: "leaf of a chain",
+			cert: leafPEM + caPEM,
+			want: map[string]string{CertificateSerialKey: "12:34:ab", CertificateExpirationKey: strconv.FormatInt(notAfter.Unix(), 10)},
+		},
+		{name: "not pem", cert: "certificate", wantErr: true},
+		{name: "truncated", cert: strings.Replace(leafPEM, "\n", "", 3), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := certificateAnnotations(map[string][]byte{v1.TLSCertKey: []byte(tt.cert)})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("certificateAnnotations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("certificateAnnotations()[%q] = %q, want %q", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func Test_timeUntilRotation(t *testing.T) {
+	now := time.Date(2017, 1, 20, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	key, err := generatePrivateKey(&kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := encodePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := generatePrivateKey(&kube.PrivateKeySpec{Algorithm: kube.PrivateKeyECDSA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKeyPEM, err := encodePrivateKey(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a certificate valid for 100 hours, with 90 left.
+	cert := testSelfSigned(t, key, now.Add(-10*time.Hour), now.Add(90*time.Hour))
+
+	tests := []struct {
+		name    string
+		spec    kube.SecretSpec
+		cert    string
+		key     []byte
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "renew seconds", spec: kube.SecretSpec{Renew: 3600 * 10}, cert: cert, key: keyPEM, want: 80 * time.Hour},
+		{name: "renew defaults to an hour", cert: cert, key: keyPEM, want: 89 * time.Hour},
+		{name: "renew before percent", spec: kube.SecretSpec{Renew: 3600, RenewBeforePercent: 30}, cert: cert, key: keyPEM, want: 60 * time.Hour},
+		{name: "renew longer than the lifetime", spec: kube.SecretSpec{Renew: 3600 * 200}, cert: cert, key: keyPEM, want: 90*time.Hour - 100*time.Hour/3},
+		{name: "certificate edited to another key", cert: cert, key: otherKeyPEM, wantErr: true},
+		{name: "invalid certificate", cert: "certificate", key: keyPEM, wantErr: true},
+		{name: "missing private key", cert: cert, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			spec.Type = v1.SecretTypeTLS
+			claim := &kube.SecretClaim{Spec: spec}
+			existing := &v1.Secret{Data: map[string][]byte{v1.TLSCertKey: []byte(tt.cert), v1.TLSPrivateKeyKey: tt.key}}
+
+			got, err := timeUntilRotation("ns/claim", claim, existing)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("timeUntilRotation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("timeUntilRotation() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
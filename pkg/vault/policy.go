@@ -0,0 +1,133 @@
+package vault
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// Effects of path rules.
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([^}]*?)\s*\}\}`)
+
+// PathPolicy restricts the vault paths claims may read. The first rule
+// matching a path decides whether a claim may read it, paths no rule matches
+// get the default effect. Policies fail closed, the default effect is deny
+// unless it is set to allow.
+type PathPolicy struct {
+	// Default is the effect for paths no rule matches, deny if empty.
+	Default string     `json:"default,omitempty"`
+	Rules   []PathRule `json:"rules"`
+}
+
+// PathRule is a glob of vault paths and whether claims may read them. In the
+// glob, * matches any characters but /, ** matches any characters, and the
+// placeholders {{namespace}} and {{name}} are replaced by the namespace and
+// name of the claim.
+type PathRule struct {
+	Path   string `json:"path"`
+	Effect string `json:"effect"`
+}
+
+// LoadPathPolicy reads a path policy from a yaml or json file.
+func LoadPathPolicy(file string) (*PathPolicy, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	policy := &PathPolicy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("vault-controller: path policy %s: %s", file, err.Error())
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("vault-controller: path policy %s: %s", file, err.Error())
+	}
+	return policy, nil
+}
+
+func (p *PathPolicy) validate() error {
+	if !validEffect(p.Default) && p.Default != "" {
+		return fmt.Errorf("default: effect must be %q or %q, got %q", EffectAllow, EffectDeny, p.Default)
+	}
+	for i, rule := range p.Rules {
+		if rule.Path == "" {
+			return fmt.Errorf("rule %d: path is required", i)
+		}
+		if !validEffect(rule.Effect) {
+			return fmt.Errorf("rule %d: effect must be %q or %q, got %q", i, EffectAllow, EffectDeny, rule.Effect)
+		}
+		for _, match := range placeholderPattern.FindAllStringSubmatch(rule.Path, -1) {
+			if match[1] != "namespace" && match[1] != "name" {
+				return fmt.Errorf("rule %d: unknown placeholder %q", i, match[0])
+			}
+		}
+		if strings.Contains(placeholderPattern.ReplaceAllString(rule.Path, ""), "{{") {
+			return fmt.Errorf("rule %d: unterminated placeholder in %q", i, rule.Path)
+		}
+	}
+	return nil
+}
+
+func validEffect(effect string) bool {
+	return effect == EffectAllow || effect == EffectDeny
+}
+
+// allowed returns whether a claim may read path, and the rule that decided
+// it, or nil if the default did.
+func (p *PathPolicy) allowed(path, namespace, name string) (bool, *PathRule) {
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.matches(path, namespace, name) {
+			return rule.Effect == EffectAllow, rule
+		}
+	}
+	return p.Default == EffectAllow, nil
+}
+
+func (r *PathRule) matches(path, namespace, name string) bool {
+	pattern := &bytes.Buffer{}
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range placeholderPattern.FindAllStringSubmatchIndex(r.Path, -1) {
+		writeGlob(pattern, r.Path[last:loc[0]])
+		switch r.Path[loc[2]:loc[3]] {
+		case "namespace":
+			pattern.WriteString(regexp.QuoteMeta(namespace))
+		case "name":
+			pattern.WriteString(regexp.QuoteMeta(name))
+		}
+		last = loc[1]
+	}
+	writeGlob(pattern, r.Path[last:])
+	pattern.WriteString("$")
+
+	matched, err := regexp.MatchString(pattern.String(), path)
+	return err == nil && matched
+}
+
+// writeGlob writes the regular expression for glob to buf.
+func writeGlob(buf *bytes.Buffer, glob string) {
+	for glob != "" {
+		i := strings.Index(glob, "*")
+		if i < 0 {
+			buf.WriteString(regexp.QuoteMeta(glob))
+			return
+		}
+		buf.WriteString(regexp.QuoteMeta(glob[:i]))
+		if strings.HasPrefix(glob[i:], "**") {
+			buf.WriteString(".*")
+			glob = glob[i+2:]
+		} else {
+			buf.WriteString("[^/]*")
+			glob = glob[i+1:]
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package vault
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_PathPolicy_allowed(t *testing.T) {
+	policy := &PathPolicy{
+		Default: EffectDeny,
+		Rules: []PathRule{
+			{Path: "secret/cluster-name/{{namespace}}/**", Effect: EffectAllow},
+			{Path: "database/creds/{{namespace}}-*", Effect: EffectAllow},
+			{Path: "pki/issue/{{ namespace }}", Effect: EffectAllow},
+			{Path: "secret/shared/{{namespace}}/{{name}}", Effect: EffectAllow},
+			{Path: "secret/shared/**", Effect: EffectDeny},
+			{Path: "secret/**", Effect: EffectAllow},
+		},
+	}
+	tests := []struct {
+		name      string
+		path      string
+		namespace string
+		claim     string
+		want      bool
+	}{
+		{name: "own namespace subtree", path: "secret/cluster-name/example/a/key", namespace: "example", want: true},
+		{name: "other namespace subtree, allowed by a later rule", path: "secret/cluster-name/other/key", namespace: "example", want: true},
+		{name: "database role of namespace", path: "database/creds/example-readonly", namespace: "example", want: true},
+		{name: "database role of other namespace", path: "database/creds/other-readonly", namespace: "example", want: false},
+		{name: "star doesn't cross segments", path: "database/creds/example-x/y", namespace: "example", want: false},
+		{name: "pki role of namespace", path: "pki/issue/example", namespace: "example", want: true},
+		{name: "pki role of namespace prefix", path: "pki/issue/examples", namespace: "example", want: false},
+		{name: "shared secret of claim", path: "secret/shared/example/app", namespace: "example", claim: "app", want: true},
+		{name: "shared secret of other claim", path: "secret/shared/example/other", namespace: "example", claim: "app", want: false},
+		{name: "namespace is matched literally", path: "pki/issue/axb", namespace: "a.b", want: false},
+		{name: "default effect", path: "aws/creds/example", namespace: "example", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := policy.allowed(tt.path, tt.namespace, tt.claim); got != tt.want {
+				t.Errorf("allowed() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_PathPolicy_allowed_default(t *testing.T) {
+	rules := []PathRule{{Path: "secret/{{namespace}}/**", Effect: EffectAllow}}
+	tests := []struct {
+		name   string
+		effect string
+		path   string
+		want   bool
+	}{
+		{name: "no default denies unmatched path", path: "secret/other/key", want: false},
+		{name: "no default allows matched path", path: "secret/example/key", want: true},
+		{name: "default deny", effect: EffectDeny, path: "secret/other/key", want: false},
+		{name: "default allow", effect: EffectAllow, path: "secret/other/key", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &PathPolicy{Default: tt.effect, Rules: rules}
+			got, rule := policy.allowed(tt.path, "example", "app")
+			if got != tt.want {
+				t.Errorf("allowed() = %t, want %t", got, tt.want)
+			}
+			if !tt.want && rule != nil {
+				t.Errorf("allowed() decided by %+v, want the default", rule)
+			}
+		})
+	}
+}
+
+func Test_LoadPathPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "path-policy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		policy  string
+		want    *PathPolicy
+		wantErr bool
+	}{
+		{
+			name:   "yaml",
+			policy: "default: deny\nrules:\n- path: database/creds/{{namespace}}-*\n  effect: allow\n",
+			want:   &PathPolicy{Default: EffectDeny, Rules: []PathRule{{Path: "database/creds/{{namespace}}-*", Effect: EffectAllow}}},
+		},
+		{
+			name:   "json",
+			policy: `{"rules": [{"path": "pki/issue/{{name}}", "effect": "deny"}]}`,
+			want:   &PathPolicy{Rules: []PathRule{{Path: "pki/issue/{{name}}", Effect: EffectDeny}}},
+		},
+		{name: "unknown effect", policy: "rules:\n- path: secret/**\n  effect: permit\n", wantErr: true},
+		{name: "unknown default", policy: "default: permit\n", wantErr: true},
+		{name: "missing path", policy: "rules:\n- effect: allow\n", wantErr: true},
+		{name: "unknown placeholder", policy: "rules:\n- path: secret/{{namespaces}}/**\n  effect: allow\n", wantErr: true},
+		{name: "unterminated placeholder", policy: "rules:\n- path: secret/{{namespace/**\n  effect: allow\n", wantErr: true},
+		{name: "invalid yaml", policy: "rules: [", wantErr: true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(dir, string(rune('a'+i)))
+			if err := ioutil.WriteFile(file, []byte(tt.policy), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := LoadPathPolicy(file)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadPathPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Default != tt.want.Default || len(got.Rules) != len(tt.want.Rules) {
+				t.Fatalf("LoadPathPolicy() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got.Rules {
+				if got.Rules[i] != tt.want.Rules[i] {
+					t.Errorf("rule %d = %+v, want %+v", i, got.Rules[i], tt.want.Rules[i])
+				}
+			}
+		})
+	}
+}
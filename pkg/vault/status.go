@@ -0,0 +1,161 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"k8s.io/client-go/pkg/api/unversioned"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+// Reasons for claim conditions.
+const (
+	ReasonCreated       = "Created"
+	ReasonAdopted       = "Adopted"
+	ReasonRenewed       = "Renewed"
+	ReasonRotated       = "Rotated"
+	ReasonUpToDate      = "UpToDate"
+	ReasonSecretReady   = "SecretReady"
+	ReasonSecretMissing = "SecretMissing"
+	ReasonLeaseExpired  = "LeaseExpired"
+	ReasonSyncFailed    = "SyncFailed"
+	ReasonPathDenied    = "PathDenied"
+	ReasonInvalidPath   = "InvalidPath"
+	ReasonWriteDenied   = "WriteDenied"
+	ReasonConflict      = "SecretConflict"
+)
+
+// syncResult describes what a sync did to the secret of a claim.
+type syncResult struct {
+	// reason is one of ReasonCreated, ReasonAdopted, ReasonRenewed,
+	// ReasonRotated or ReasonUpToDate.
+	reason string
+	// secret is the secret after the sync.
+	secret *v1.Secret
+}
+
+// claimError is an error with the reason it is reported with on the claim.
+type claimError struct {
+	reason string
+	err    error
+}
+
+func (e *claimError) Error() string {
+	return e.err.Error()
+}
+
+func reasonForError(err error) string {
+	if cerr, ok := err.(*claimError); ok {
+		return cerr.reason
+	}
+	return ReasonSyncFailed
+}
+
+// updateStatus records the result of a sync on the claim status, if it
+// changed.
+func (ctrl *controller) updateStatus(key string, claim *kube.SecretClaim, result *syncResult, syncErr error) {
+	status := buildStatus(claim, result, syncErr)
+	if statusEqual(claim.Status, status) {
+		return
+	}
+
+	updated := *claim
+	updated.Status = status
+	err := ctrl.claims.Put().
+		Namespace(claim.Namespace).
+		Resource(kube.ResourceSecretClaims).
+		Name(claim.Name).
+		SubResource("status").
+		Body(&updated).
+		Do().
+		Error()
+	if err != nil {
+		log.Printf("vault-controller: %s: failed to update status: %s", key, err.Error())
+	}
+}
+
+// buildStatus returns the claim status after a sync. If result is nil the
+// state of the secret is unknown and the ready condition is left unchanged.
+func buildStatus(claim *kube.SecretClaim, result *syncResult, syncErr error) kube.SecretClaimStatus {
+	now := unversioned.NewTime(timeNow())
+	status := claim.Status
+	status.ObservedGeneration = claim.Generation
+	status.Conditions = append([]kube.SecretClaimCondition(nil), claim.Status.Conditions...)
+
+	if syncErr != nil {
+		status.LastError = syncErr.Error()
+		setCondition(&status, kube.SecretClaimSynced, v1.ConditionFalse, reasonForError(syncErr), syncErr.Error(), now)
+	} else {
+		status.LastError = ""
+		setCondition(&status, kube.SecretClaimSynced, v1.ConditionTrue, result.reason, "", now)
+	}
+
+	if result == nil {
+		return status
+	}
+
+	switch result.reason {
+	case ReasonRenewed:
+		status.LastRenewTime = &now
+	case ReasonCreated, ReasonAdopted, ReasonRotated:
+		status.LastRotationTime = &now
+	}
+
+	if result.secret == nil {
+		status.LeaseExpiration = nil
+		setCondition(&status, kube.SecretClaimReady, v1.ConditionFalse, ReasonSecretMissing, "secret does not exist", now)
+		return status
+	}
+
+	status.LeaseExpiration = nil
+	if raw, ok := result.secret.Annotations[LeaseExpirationKey]; ok {
+		if expiration, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			t := unversioned.NewTime(time.Unix(expiration, 0))
+			status.LeaseExpiration = &t
+		}
+	}
+
+	leased := result.secret.Annotations[LeaseIDKey] != ""
+	if leased && status.LeaseExpiration != nil && !status.LeaseExpiration.After(now.Time) {
+		setCondition(&status, kube.SecretClaimReady, v1.ConditionFalse, ReasonLeaseExpired, "lease has expired", now)
+	} else {
+		setCondition(&status, kube.SecretClaimReady, v1.ConditionTrue, ReasonSecretReady, "", now)
+	}
+	return status
+}
+
+// setCondition sets a condition, keeping its transition time unless the
+// status changed.
+func setCondition(status *kube.SecretClaimStatus, typ kube.SecretClaimConditionType, value v1.ConditionStatus, reason, message string, now unversioned.Time) {
+	condition := kube.SecretClaimCondition{
+		Type:               typ,
+		Status:             value,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+
+	for i, existing := range status.Conditions {
+		if existing.Type != typ {
+			continue
+		}
+		if existing.Status == value {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		status.Conditions[i] = condition
+		return
+	}
+	status.Conditions = append(status.Conditions, condition)
+}
+
+// statusEqual compares statuses as serialized, so times are compared at the
+// precision they are stored with.
+func statusEqual(a, b kube.SecretClaimStatus) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(rawA, rawB)
+}
@@ -0,0 +1,115 @@
+package vault
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"k8s.io/client-go/pkg/api"
+	"k8s.io/client-go/pkg/api/unversioned"
+	v1 "k8s.io/client-go/pkg/api/v1"
+)
+
+func Test_buildStatus(t *testing.T) {
+	now := unversioned.NewTime(timeNow())
+	earlier := unversioned.NewTime(timeNow().Add(-time.Hour))
+	expiration := unversioned.NewTime(timeNow().Add(time.Hour))
+	expired := unversioned.NewTime(timeNow().Add(-time.Minute))
+
+	withLease := func(expiration unversioned.Time) *v1.Secret {
+		return &v1.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Annotations: map[string]string{
+					LeaseIDKey:         "secret/lease",
+					LeaseExpirationKey: strconv.FormatInt(expiration.Unix(), 10),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		claim  *kube.SecretClaim
+		result *syncResult
+		err    error
+		want   kube.SecretClaimStatus
+	}{
+		{
+			name:   "created secret",
+			claim:  &kube.SecretClaim{ObjectMeta: api.ObjectMeta{Generation: 2}},
+			result: &syncResult{reason: ReasonCreated, secret: withLease(expiration)},
+			want: kube.SecretClaimStatus{
+				ObservedGeneration: 2,
+				Conditions: []kube.SecretClaimCondition{
+					{Type: kube.SecretClaimSynced, Status: v1.ConditionTrue, LastTransitionTime: now, Reason: ReasonCreated},
+					{Type: kube.SecretClaimReady, Status: v1.ConditionTrue, LastTransitionTime: now, Reason: ReasonSecretReady},
+				},
+				LeaseExpiration:  &expiration,
+				LastRotationTime: &now,
+			},
+		},
+		{
+			name: "renewed secret keeps transition times",
+			claim: &kube.SecretClaim{
+				Status: kube.SecretClaimStatus{
+					Conditions: []kube.SecretClaimCondition{
+						{Type: kube.SecretClaimSynced, Status: v1.ConditionTrue, LastTransitionTime: earlier, Reason: ReasonCreated},
+						{Type: kube.SecretClaimReady, Status: v1.ConditionTrue, LastTransitionTime: earlier, Reason: ReasonSecretReady},
+					},
+					LastRotationTime: &earlier,
+				},
+			},
+			result: &syncResult{reason: ReasonRenewed, secret: withLease(expiration)},
+			want: kube.SecretClaimStatus{
+				Conditions: []kube.SecretClaimCondition{
+					{Type: kube.SecretClaimSynced, Status: v1.ConditionTrue, LastTransitionTime: earlier, Reason: ReasonRenewed},
+					{Type: kube.SecretClaimReady, Status: v1.ConditionTrue, LastTransitionTime: earlier, Reason: ReasonSecretReady},
+				},
+				LeaseExpiration:  &expiration,
+				LastRenewTime:    &now,
+				LastRotationTime: &earlier,
+			},
+		},
+		{
+			name:   "expired lease",
+			claim:  &kube.SecretClaim{},
+			result: &syncResult{reason: ReasonUpToDate, secret: withLease(expired)},
+			want: kube.SecretClaimStatus{
+				Conditions: []kube.SecretClaimCondition{
+					{Type: kube.SecretClaimSynced, Status: v1.ConditionTrue, LastTransitionTime: now, Reason: ReasonUpToDate},
+					{Type: kube.SecretClaimReady, Status: v1.ConditionFalse, LastTransitionTime: now, Reason: ReasonLeaseExpired, Message: "lease has expired"},
+				},
+				LeaseExpiration: &expired,
+			},
+		},
+		{
+			name: "failed sync leaves ready unchanged",
+			claim: &kube.SecretClaim{
+				Status: kube.SecretClaimStatus{
+					Conditions: []kube.SecretClaimCondition{
+						{Type: kube.SecretClaimSynced, Status: v1.ConditionTrue, LastTransitionTime: earlier, Reason: ReasonCreated},
+						{Type: kube.SecretClaimReady, Status: v1.ConditionTrue, LastTransitionTime: earlier, Reason: ReasonSecretReady},
+					},
+				},
+			},
+			err: &claimError{reason: ReasonPathDenied, err: errors.New("denied")},
+			want: kube.SecretClaimStatus{
+				Conditions: []kube.SecretClaimCondition{
+					{Type: kube.SecretClaimSynced, Status: v1.ConditionFalse, LastTransitionTime: now, Reason: ReasonPathDenied, Message: "denied"},
+					{Type: kube.SecretClaimReady, Status: v1.ConditionTrue, LastTransitionTime: earlier, Reason: ReasonSecretReady},
+				},
+				LastError: "denied",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildStatus(tt.claim, tt.result, tt.err)
+			if !statusEqual(got, tt.want) {
+				t.Errorf("buildStatus() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
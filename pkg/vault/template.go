@@ -0,0 +1,82 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"text/template"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+)
+
+// templateContext is the value secret templates are executed against.
+type templateContext struct {
+	Data      map[string]interface{}
+	Lease     templateLease
+	Namespace string
+	Name      string
+}
+
+type templateLease struct {
+	ID        string
+	Duration  int
+	Renewable bool
+}
+
+var templateFuncs = template.FuncMap{
+	"base64": func(val interface{}) string {
+		return base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(val)))
+	},
+	"toJSON": func(val interface{}) (string, error) {
+		raw, err := json.Marshal(val)
+		return string(raw), err
+	},
+	"default": func(def, val interface{}) interface{} {
+		if val == nil {
+			return def
+		}
+		if str, ok := val.(string); ok && str == "" {
+			return def
+		}
+		return val
+	},
+}
+
+// renderTemplates executes the claim templates against the vault response,
+// returning the rendered value for each key. Any failure fails all keys.
+func renderTemplates(claim *kube.SecretClaim, secret *vaultapi.Secret) (map[string][]byte, error) {
+	ctx := &templateContext{
+		Data: secret.Data,
+		Lease: templateLease{
+			ID:        secret.LeaseID,
+			Duration:  secret.LeaseDuration,
+			Renewable: secret.Renewable,
+		},
+		Namespace: claim.Namespace,
+		Name:      claim.Name,
+	}
+
+	keys := make([]string, 0, len(claim.Spec.Template))
+	for key := range claim.Spec.Template {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	rendered := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		tmpl, err := template.New(key).Funcs(templateFuncs).Option("missingkey=error").Parse(claim.Spec.Template[key])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template for key %s: %s", key, err.Error())
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, ctx); err != nil {
+			return nil, fmt.Errorf("failed to render template for key %s: %s", key, err.Error())
+		}
+		rendered[key] = buf.Bytes()
+	}
+	return rendered, nil
+}
@@ -0,0 +1,389 @@
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+)
+
+const (
+	tokenFilePollInterval = 10 * time.Second
+	tokenIdleInterval     = 5 * time.Minute
+	tokenRetryInterval    = 30 * time.Second
+)
+
+// TokenConfig configures where the controller token is loaded from. Without
+// a file the token is read from the environment (VAULT_TOKEN).
+type TokenConfig struct {
+	// File is read for the token, and watched for changes.
+	File string
+	// WrappedFile is read for a response wrapping token, which is unwrapped
+	// once at startup. It can't be used with File.
+	WrappedFile string
+}
+
+// Token manages the controller's own vault token, keeping it renewed and
+// reloading it from file when it changes.
+type Token struct {
+	vconfig *vaultapi.Config
+	file    string
+
+	mu        sync.RWMutex
+	client    *vaultapi.Client
+	fileToken string
+	// ttl is the ttl the token was created or last renewed with, the
+	// renewal window, not the time remaining.
+	ttl        time.Duration
+	expiration time.Time
+	renewable  bool
+	// renewErr is kept until the token is renewed or replaced, lookupErr
+	// and reloadErr until the next lookup or reload succeeds.
+	renewErr  error
+	lookupErr error
+	reloadErr error
+}
+
+func NewToken(vconfig *vaultapi.Config, config *TokenConfig) (*Token, error) {
+	if config.File != "" && config.WrappedFile != "" {
+		return nil, errors.New("vault-token: a token file and a wrapped token file can't be used together")
+	}
+
+	client, err := newClient(vconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Token{
+		vconfig: vconfig,
+		file:    config.File,
+		client:  client,
+	}
+
+	switch {
+	case config.WrappedFile != "":
+		wrapped, err := readTokenFile(config.WrappedFile)
+		if err != nil {
+			return nil, err
+		}
+		token, err := t.unwrap(wrapped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap token from %s: %s", config.WrappedFile, err.Error())
+		}
+		client.SetToken(token)
+		log.Printf("vault-token: unwrapped token from %s", config.WrappedFile)
+	case config.File != "":
+		token, err := readTokenFile(config.File)
+		if err != nil {
+			return nil, err
+		}
+		client.SetToken(token)
+		t.fileToken = token
+		log.Printf("vault-token: read token from %s", config.File)
+	}
+
+	if err := t.lookup(); err != nil {
+		log.Printf("vault-token: failed to lookup token: %s", err.Error())
+	}
+	return t, nil
+}
+
+// Client returns a vault client using the current token. Clients are never
+// modified once returned, a changed token results in a new client.
+func (t *Token) Client() *vaultapi.Client {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.client
+}
+
+// Healthy returns an error if the token failed to renew or has expired.
+func (t *Token) Healthy() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, err := range []error{t.renewErr, t.lookupErr, t.reloadErr} {
+		if err != nil {
+			return err
+		}
+	}
+	if t.ttl > 0 && !t.expiration.After(timeNow()) {
+		return errors.New("vault token expired")
+	}
+	return nil
+}
+
+// TTL returns the time remaining until the token expires, or zero if the
+// token does not expire.
+func (t *Token) TTL() time.Duration {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.ttl == 0 {
+		return 0
+	}
+	return t.expiration.Sub(timeNow())
+}
+
+// Run renews the token and watches the token file until stop is signalled.
+func (t *Token) Run(stop chan struct{}) {
+	for {
+		wait := t.sync()
+		select {
+		case <-stop:
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// sync reloads and renews the token as needed, returning the time until it
+// should be called again.
+func (t *Token) sync() time.Duration {
+	if t.file != "" {
+		if err := t.reload(); err != nil {
+			log.Printf("vault-token: failed to reload token from %s: %s", t.file, err.Error())
+		}
+	}
+
+	wait := t.renew()
+	if t.file != "" && wait > tokenFilePollInterval {
+		wait = tokenFilePollInterval
+	}
+	return wait
+}
+
+// renew renews the token once half of its ttl has passed, returning the time
+// until the next renewal is due. A failed renewal is retried, and reported by
+// Healthy until a renewal succeeds.
+func (t *Token) renew() time.Duration {
+	t.mu.RLock()
+	ttl, expiration, renewable := t.ttl, t.expiration, t.renewable
+	renewErr, lookupErr := t.renewErr, t.lookupErr
+	t.mu.RUnlock()
+
+	if lookupErr != nil || (ttl == 0 && expiration.IsZero()) {
+		if err := t.lookup(); err != nil {
+			log.Printf("vault-token: failed to lookup token: %s", err.Error())
+			return tokenRetryInterval
+		}
+		return t.renew()
+	}
+	if ttl == 0 {
+		return tokenIdleInterval
+	}
+
+	remaining := expiration.Sub(timeNow())
+	if remaining > ttl/2 && renewErr == nil {
+		return remaining - ttl/2
+	}
+	if !renewable {
+		log.Printf("vault-token: token is not renewable, expires in %s", remaining)
+		if remaining <= 0 {
+			return tokenRetryInterval
+		}
+		return remaining / 2
+	}
+
+	start := time.Now()
+	secret, err := t.Client().Auth().Token().RenewSelf(0)
+	observeVault("renew_token", start, err)
+	if err == nil && (secret == nil || secret.Auth == nil) {
+		err = errors.New("renew response did not include auth")
+	}
+	if err != nil {
+		log.Printf("vault-token: failed to renew token, expires in %s: %s", remaining, err.Error())
+		t.mu.Lock()
+		t.renewErr = fmt.Errorf("failed to renew vault token: %s", err.Error())
+		t.mu.Unlock()
+
+		// the token may have been renewed or revoked elsewhere.
+		if err := t.lookup(); err != nil {
+			log.Printf("vault-token: failed to lookup token: %s", err.Error())
+		}
+		t.mu.RLock()
+		remaining = t.expiration.Sub(timeNow())
+		t.mu.RUnlock()
+
+		if remaining/4 < tokenRetryInterval && remaining > 0 {
+			return remaining / 4
+		}
+		return tokenRetryInterval
+	}
+
+	t.mu.Lock()
+	t.ttl = time.Duration(secret.Auth.LeaseDuration) * time.Second
+	t.expiration = timeNow().Add(t.ttl)
+	t.renewable = secret.Auth.Renewable
+	t.renewErr = nil
+	t.mu.Unlock()
+
+	log.Printf("vault-token: token renewed for %s", t.ttl)
+	if t.ttl == 0 {
+		return tokenIdleInterval
+	}
+	return t.ttl / 2
+}
+
+// lookup refreshes the expiration of the current token with lookup-self. The
+// ttl is the creation ttl of the token, or the ttl it was last renewed with,
+// so renewals stay at half of the full window.
+func (t *Token) lookup() error {
+	secret, err := t.Client().Auth().Token().LookupSelf()
+	if err == nil && (secret == nil || secret.Data == nil) {
+		err = errors.New("lookup response did not include data")
+	}
+	var ttl, creationTTL int64
+	if err == nil {
+		ttl, err = secretInt(secret.Data["ttl"])
+	}
+	if err == nil && secret.Data["creation_ttl"] != nil {
+		creationTTL, err = secretInt(secret.Data["creation_ttl"])
+	}
+	if err != nil {
+		t.mu.Lock()
+		t.lookupErr = err
+		t.mu.Unlock()
+		return err
+	}
+	renewable, _ := secret.Data["renewable"].(bool)
+	remaining := time.Duration(ttl) * time.Second
+
+	t.mu.Lock()
+	switch {
+	case remaining == 0:
+		t.ttl = 0
+	case creationTTL > 0:
+		t.ttl = time.Duration(creationTTL) * time.Second
+	case t.ttl == 0:
+		t.ttl = remaining
+	}
+	t.expiration = timeNow().Add(remaining)
+	t.renewable = renewable
+	t.lookupErr = nil
+	t.mu.Unlock()
+
+	if ttl == 0 {
+		log.Printf("vault-token: token does not expire")
+	} else {
+		log.Printf("vault-token: token expires in %s (renewable=%t)", remaining, renewable)
+	}
+	return nil
+}
+
+// reload replaces the client if the token file has changed.
+func (t *Token) reload() error {
+	token, err := readTokenFile(t.file)
+	if err == nil {
+		t.mu.RLock()
+		unchanged := token == t.fileToken
+		t.mu.RUnlock()
+		if unchanged {
+			t.setReloadErr(nil)
+			return nil
+		}
+	}
+
+	var client *vaultapi.Client
+	if err == nil {
+		client, err = newClient(t.vconfig)
+	}
+	if err != nil {
+		t.setReloadErr(err)
+		return err
+	}
+	client.SetToken(token)
+
+	t.mu.Lock()
+	t.client = client
+	t.fileToken = token
+	t.ttl = 0
+	t.expiration = time.Time{}
+	t.renewErr = nil
+	t.lookupErr = nil
+	t.reloadErr = nil
+	t.mu.Unlock()
+
+	log.Printf("vault-token: token file %s changed, reloaded token", t.file)
+	return t.lookup()
+}
+
+func (t *Token) unwrap(wrapped string) (string, error) {
+	client, err := newClient(t.vconfig)
+	if err != nil {
+		return "", err
+	}
+	client.SetToken(wrapped)
+
+	secret, err := client.Logical().Unwrap(wrapped)
+	if err != nil {
+		return "", err
+	}
+	if secret == nil {
+		return "", errors.New("no wrapped response found")
+	}
+	if secret.Auth != nil && secret.Auth.ClientToken != "" {
+		return secret.Auth.ClientToken, nil
+	}
+	if token, ok := secret.Data["token"].(string); ok && token != "" {
+		return token, nil
+	}
+	return "", errors.New("wrapped response did not include a token")
+}
+
+func (t *Token) setReloadErr(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.reloadErr = err
+}
+
+// newClient returns a vault client for vconfig. NewClient configures http2 on
+// the transport of the config it is given, which fails the second time, so
+// each client gets a copy of the config with its own transport.
+func newClient(vconfig *vaultapi.Config) (*vaultapi.Client, error) {
+	config := &vaultapi.Config{
+		Address:    vconfig.Address,
+		MaxRetries: vconfig.MaxRetries,
+	}
+	if vconfig.HttpClient != nil {
+		httpClient := *vconfig.HttpClient
+		if transport, ok := httpClient.Transport.(*http.Transport); ok {
+			httpClient.Transport = transport.Clone()
+		}
+		config.HttpClient = &httpClient
+	}
+	return vaultapi.NewClient(config)
+}
+
+func readTokenFile(file string) (string, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(raw))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", file)
+	}
+	return token, nil
+}
+
+// secretInt converts a number decoded from a vault response.
+func secretInt(val interface{}) (int64, error) {
+	switch num := val.(type) {
+	case json.Number:
+		return num.Int64()
+	case float64:
+		return int64(num), nil
+	case int:
+		return int64(num), nil
+	case int64:
+		return num, nil
+	}
+	return 0, fmt.Errorf("expected a number, got %T", val)
+}
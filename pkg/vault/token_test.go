@@ -0,0 +1,378 @@
+package vault
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	"github.com/roboll/kube-vault-controller/pkg/kube"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// fakeVault serves vault api requests from handlers keyed by method and path,
+// e.g. "GET /v1/auth/token/lookup-self", and records the token of each.
+type fakeVault struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	tokens   map[string][]string
+}
+
+func newFakeVault(t *testing.T) *fakeVault {
+	v := &fakeVault{handlers: map[string]http.HandlerFunc{}, tokens: map[string][]string{}}
+	v.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		key := req.Method + " " + req.URL.Path
+		v.mu.Lock()
+		handler, ok := v.handlers[key]
+		v.tokens[key] = append(v.tokens[key], req.Header.Get("X-Vault-Token"))
+		v.mu.Unlock()
+		if !ok {
+			t.Logf("fake vault: unexpected request %s", key)
+			http.Error(w, `{"errors":["not found"]}`, http.StatusNotFound)
+			return
+		}
+		handler(w, req)
+	}))
+	return v
+}
+
+// handle sets the handler of key, replacing any previous one.
+func (v *fakeVault) handle(key string, handler http.HandlerFunc) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.handlers[key] = handler
+}
+
+// respond sets key to respond with status and body.
+func (v *fakeVault) respond(key string, status int, body string) {
+	v.handle(key, func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	})
+}
+
+// requests returns the tokens of the requests made to key.
+func (v *fakeVault) requests(key string) []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]string(nil), v.tokens[key]...)
+}
+
+func (v *fakeVault) config() *vaultapi.Config {
+	config := vaultapi.DefaultConfig()
+	config.Address = v.URL
+	config.MaxRetries = 0
+	return config
+}
+
+// newTestController returns a controller with a token that doesn't expire,
+// whose vault and kubernetes api are both served by vault. Tests set the
+// other fields they need.
+func newTestController(t *testing.T, vault *fakeVault) *controller {
+	vault.respond(lookupSelf, http.StatusOK, lookupResponse(0, 0))
+	token, err := NewToken(vault.config(), &TokenConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := &rest.Config{Host: vault.URL}
+	kclient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims, err := kube.NewSecretClaimClient(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &controller{
+		token:   token,
+		kclient: kclient,
+		claims:  claims,
+		tokens:  newTokenCache(vault.config()),
+	}
+}
+
+const (
+	lookupSelf = "GET /v1/auth/token/lookup-self"
+	renewSelf  = "PUT /v1/auth/token/renew-self"
+	unwrap     = "PUT /v1/sys/wrapping/unwrap"
+	sysMounts  = "GET /v1/sys/mounts"
+)
+
+func lookupResponse(ttl, creationTTL int) string {
+	return fmt.Sprintf(`{"data":{"ttl":%d,"creation_ttl":%d,"renewable":true}}`, ttl, creationTTL)
+}
+
+// fixTime stops the clock for a test, returning a func that restarts it.
+func fixTime() func() {
+	now, previous := timeNow(), timeNow
+	timeNow = func() time.Time { return now }
+	return func() { timeNow = previous }
+}
+
+func writeTokenFile(t *testing.T, dir, token string) string {
+	file := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(file, []byte(token+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func Test_NewToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := writeTokenFile(t, dir, "file-token")
+	wrappedFile := filepath.Join(dir, "wrapped")
+	if err := ioutil.WriteFile(wrappedFile, []byte("wrapping-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	vault := newFakeVault(t)
+	defer vault.Close()
+	vault.respond(lookupSelf, http.StatusOK, lookupResponse(3600, 3600))
+	vault.respond(unwrap, http.StatusOK, `{"auth":{"client_token":"unwrapped-token"}}`)
+
+	tests := []struct {
+		name    string
+		config  *TokenConfig
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "token file",
+			config: &TokenConfig{File: file},
+			want:   "file-token",
+		},
+		{
+			name:   "wrapped token file",
+			config: &TokenConfig{WrappedFile: wrappedFile},
+			want:   "unwrapped-token",
+		},
+		{
+			name:    "token file and wrapped token file",
+			config:  &TokenConfig{File: file, WrappedFile: wrappedFile},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := NewToken(vault.config(), tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := token.Client().Token(); got != tt.want {
+				t.Errorf("NewToken() token = %q, want %q", got, tt.want)
+			}
+			// a sync must not replace the token it was started with.
+			token.sync()
+			if got := token.Client().Token(); got != tt.want {
+				t.Errorf("sync() token = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_Token_lookup(t *testing.T) {
+	defer fixTime()()
+
+	tests := []struct {
+		name       string
+		response   string
+		status     int
+		ttl        time.Duration
+		wantTTL    time.Duration
+		wantRemain time.Duration
+		wantErr    bool
+	}{
+		{
+			name:       "keeps creation ttl",
+			response:   lookupResponse(600, 3600),
+			status:     http.StatusOK,
+			wantTTL:    time.Hour,
+			wantRemain: 10 * time.Minute,
+		},
+		{
+			name:       "remaining ttl without creation ttl",
+			response:   `{"data":{"ttl":600,"renewable":true}}`,
+			status:     http.StatusOK,
+			wantTTL:    10 * time.Minute,
+			wantRemain: 10 * time.Minute,
+		},
+		{
+			name:       "keeps renewed ttl without creation ttl",
+			response:   `{"data":{"ttl":600,"renewable":true}}`,
+			status:     http.StatusOK,
+			ttl:        time.Hour,
+			wantTTL:    time.Hour,
+			wantRemain: 10 * time.Minute,
+		},
+		{
+			name:     "token does not expire",
+			response: lookupResponse(0, 0),
+			status:   http.StatusOK,
+			ttl:      time.Hour,
+		},
+		{
+			name:     "lookup fails",
+			response: `{"errors":["permission denied"]}`,
+			status:   http.StatusForbidden,
+			ttl:      time.Hour,
+			wantTTL:  time.Hour,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vault := newFakeVault(t)
+			defer vault.Close()
+			vault.respond(lookupSelf, tt.status, tt.response)
+
+			token, err := NewToken(vault.config(), &TokenConfig{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			token.ttl = tt.ttl
+			token.lookupErr = nil
+
+			err = token.lookup()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (token.Healthy() != nil) != tt.wantErr {
+				t.Errorf("Healthy() = %v, wantErr %v", token.Healthy(), tt.wantErr)
+			}
+			if token.ttl != tt.wantTTL {
+				t.Errorf("lookup() ttl = %s, want %s", token.ttl, tt.wantTTL)
+			}
+			if !tt.wantErr && token.TTL() != tt.wantRemain {
+				t.Errorf("TTL() = %s, want %s", token.TTL(), tt.wantRemain)
+			}
+		})
+	}
+}
+
+func Test_Token_renew(t *testing.T) {
+	defer fixTime()()
+
+	vault := newFakeVault(t)
+	defer vault.Close()
+	vault.respond(lookupSelf, http.StatusOK, lookupResponse(1000, 3600))
+	vault.respond(renewSelf, http.StatusInternalServerError, `{"errors":["internal error"]}`)
+
+	token, err := NewToken(vault.config(), &TokenConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each failed renewal looks the token up again, which succeeds, and must
+	// not clear the renewal error.
+	for i := 0; i < 3; i++ {
+		if wait := token.renew(); wait != tokenRetryInterval {
+			t.Errorf("renew() %d = %s, want %s", i, wait, tokenRetryInterval)
+		}
+		if token.Healthy() == nil {
+			t.Errorf("Healthy() after failed renewal %d = nil, want error", i)
+		}
+		if token.ttl != time.Hour {
+			t.Errorf("renew() %d ttl = %s, want %s", i, token.ttl, time.Hour)
+		}
+	}
+	if got := len(vault.requests(renewSelf)); got != 3 {
+		t.Errorf("renew() made %d renewals, want 3", got)
+	}
+
+	vault.respond(renewSelf, http.StatusOK, `{"auth":{"client_token":"token","lease_duration":3600,"renewable":true}}`)
+	if wait := token.renew(); wait != 30*time.Minute {
+		t.Errorf("renew() = %s, want %s", wait, 30*time.Minute)
+	}
+	if err := token.Healthy(); err != nil {
+		t.Errorf("Healthy() after renewal = %v, want nil", err)
+	}
+	if wait := token.renew(); wait != 30*time.Minute {
+		t.Errorf("renew() before half ttl = %s, want %s", wait, 30*time.Minute)
+	}
+	if got := len(vault.requests(renewSelf)); got != 4 {
+		t.Errorf("renew() made %d renewals, want 4", got)
+	}
+}
+
+func Test_Token_reload(t *testing.T) {
+	defer fixTime()()
+
+	dir, err := ioutil.TempDir("", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := writeTokenFile(t, dir, "first")
+
+	vault := newFakeVault(t)
+	defer vault.Close()
+	vault.respond(lookupSelf, http.StatusOK, lookupResponse(3600, 3600))
+	vault.respond(renewSelf, http.StatusInternalServerError, `{"errors":["internal error"]}`)
+
+	token, err := NewToken(vault.config(), &TokenConfig{File: file})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token.expiration = timeNow().Add(time.Minute)
+	token.renew()
+	if token.Healthy() == nil {
+		t.Fatal("Healthy() after failed renewal = nil, want error")
+	}
+
+	if err := token.reload(); err != nil {
+		t.Fatalf("reload() unchanged error = %v", err)
+	}
+	if token.Healthy() == nil {
+		t.Error("Healthy() after unchanged reload = nil, want the renewal error")
+	}
+
+	writeTokenFile(t, dir, "second")
+	if err := token.reload(); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	if got := token.Client().Token(); got != "second" {
+		t.Errorf("reload() token = %q, want %q", got, "second")
+	}
+	if err := token.Healthy(); err != nil {
+		t.Errorf("Healthy() after reload = %v, want nil", err)
+	}
+	if got := vault.requests(lookupSelf); got[len(got)-1] != "second" {
+		t.Errorf("reload() looked up %q, want %q", got[len(got)-1], "second")
+	}
+
+	os.Remove(file)
+	if err := token.reload(); err == nil {
+		t.Error("reload() of missing file error = nil, want error")
+	}
+	if token.Healthy() == nil {
+		t.Error("Healthy() after failed reload = nil, want error")
+	}
+	if got := token.Client().Token(); got != "second" {
+		t.Errorf("failed reload() token = %q, want %q", got, "second")
+	}
+
+	writeTokenFile(t, dir, "second")
+	if err := token.reload(); err != nil {
+		t.Fatalf("reload() error = %v", err)
+	}
+	if err := token.Healthy(); err != nil {
+		t.Errorf("Healthy() after restored file = %v, want nil", err)
+	}
+}